@@ -0,0 +1,401 @@
+package main
+
+import (
+	"crypto"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mayo-go/envelope"
+	"mayo-go/flags"
+	"mayo-go/hd"
+	"mayo-go/mayo"
+	"mayo-go/mnemonic"
+	"mayo-go/shamir"
+	"mayo-go/x509"
+	"os"
+	"slices"
+	"strings"
+)
+
+func keyGen(arguments *flags.KeyGenArguments) error {
+	// Initialize MAYO
+	m, err := mayo.InitMayo(arguments.ParameterSet)
+	if err != nil {
+		return err
+	}
+
+	// Generate the public key and secret key
+	pk, sk, err := m.GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	// Write the key files
+	if err = writePublicKey(arguments.Out+publicKeyExtension, pk); err != nil {
+		return err
+	}
+	return writePrivateKey(arguments.Out+secretKeyExtension, sk, arguments.Plaintext)
+}
+
+func sign(arguments *flags.SignArguments) error {
+	sk, err := readPrivateKey(arguments.Key)
+	if err != nil {
+		return err
+	}
+
+	var output []byte
+	if arguments.Attached {
+		message, err := readInput(arguments.In)
+		if err != nil {
+			return err
+		}
+		if arguments.Envelope {
+			output, err = envelope.Sign(sk, message, nil)
+		} else {
+			output, err = sk.Mayo().APISign(message, sk.Bytes())
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		// Stream the message, as a detached signature does not need it in memory
+		signer, err := sk.Mayo().NewSigner(sk.Expand().Bytes())
+		if err != nil {
+			return err
+		}
+		if err = copyInput(signer, arguments.In); err != nil {
+			return err
+		}
+		output, err = signer.Sign()
+		if err != nil {
+			return err
+		}
+
+		if arguments.Envelope {
+			s := &envelope.Signature{Mayo: sk.Mayo(), KeyID: sk.Public().(*mayo.PublicKey).KeyID(), Signature: output}
+			if output, err = s.Marshal(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writeOutput(arguments.Out, output)
+}
+
+func verify(arguments *flags.VerifyArguments) error {
+	pk, err := readPublicKey(arguments.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if !arguments.Attached {
+		sig, err := readInput(arguments.Signature)
+		if err != nil {
+			return err
+		}
+		// A raw signature may begin with the magic of an envelope, thus it is only an envelope if it can be parsed
+		if s, err := envelope.ParseSignature(sig); err == nil {
+			return verifyDetachedEnvelope(pk, s, arguments.In)
+		}
+
+		// Stream the message, as a detached signature does not need it in memory
+		if err = verifyStream(pk, sig, arguments.In); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Signature is valid")
+		return nil
+	}
+
+	input, err := readInput(arguments.In)
+	if err != nil {
+		return err
+	}
+
+	var message []byte
+	if s, parseErr := envelope.ParseSignature(input); parseErr == nil {
+		if s.Message == nil {
+			return errors.New("the signature envelope is detached, verify it with -sig")
+		}
+		if message, err = s.Verify(pk, nil); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidSignature, err)
+		}
+	} else {
+		var result int
+		result, message, err = pk.Mayo().APISignOpen(input, pk.Bytes())
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidSignature, err)
+		} else if result != 0 {
+			return errInvalidSignature
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "Signature is valid")
+	if arguments.Out != "" {
+		return writeOutput(arguments.Out, message)
+	}
+	return nil
+}
+
+// verifyDetachedEnvelope verifies a parsed signature envelope on the message at path. The message is streamed, unless
+// the signature has a context string or is pre-hashed.
+func verifyDetachedEnvelope(pk *mayo.PublicKey, s *envelope.Signature, path string) error {
+	if s.Message != nil {
+		return errors.New("the signature envelope holds the message, verify it with -attached")
+	}
+
+	var err error
+	if s.Context == nil && s.Hash == crypto.Hash(0) {
+		if err = s.CheckPublicKey(pk); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidSignature, err)
+		}
+		err = verifyStream(pk, s.Signature, path)
+	} else {
+		var message []byte
+		if message, err = readInput(path); err != nil {
+			return err
+		}
+		if _, err = s.Verify(pk, message); err != nil {
+			err = fmt.Errorf("%w: %w", errInvalidSignature, err)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Signature by key %s is valid\n", s.KeyID)
+	return nil
+}
+
+// verifyStream verifies the detached signature sig on the message at path, which is streamed
+func verifyStream(pk *mayo.PublicKey, sig []byte, path string) error {
+	verifier, err := pk.Mayo().NewVerifier(pk.Expand().Bytes())
+	if err != nil {
+		return err
+	}
+	if err = copyInput(verifier, path); err != nil {
+		return err
+	}
+	if result, err := verifier.Verify(sig); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSignature, err)
+	} else if result != 0 {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+func createCSR(arguments *flags.CSRArguments) error {
+	sk, err := readPrivateKey(arguments.Key)
+	if err != nil {
+		return err
+	}
+
+	subject := pkix.Name{CommonName: arguments.CommonName}
+	if arguments.Organization != "" {
+		subject.Organization = []string{arguments.Organization}
+	}
+
+	der, err := x509.CreateCertificateRequest(&x509.CertificateRequest{Subject: subject}, sk)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(arguments.Out, x509.EncodeCertificateRequestPEM(der))
+}
+
+func derive(arguments *flags.DeriveArguments) error {
+	// Initialize MAYO
+	m, err := mayo.InitMayo(arguments.ParameterSet)
+	if err != nil {
+		return err
+	}
+
+	// Read the master seed and derive the node of the path
+	content, err := readInput(arguments.Seed)
+	if err != nil {
+		return err
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return fmt.Errorf("master seed is not hex encoded: %w", err)
+	}
+	master, err := hd.NewMaster(seed)
+	if err != nil {
+		return err
+	}
+	node, err := master.Derive(arguments.Path)
+	if err != nil {
+		return err
+	}
+
+	pk, sk, err := node.GenerateKey(m)
+	if err != nil {
+		return err
+	}
+
+	// Write the key files, if requested
+	if arguments.Out != "" {
+		if err = writePublicKey(arguments.Out+publicKeyExtension, pk); err != nil {
+			return err
+		}
+		if err = writePrivateKey(arguments.Out+secretKeyExtension, sk, arguments.Plaintext); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(hex.EncodeToString(pk.Bytes()))
+	return nil
+}
+
+func keyBackup(arguments *flags.KeyBackupArguments) error {
+	sk, err := readPrivateKey(arguments.Key)
+	if err != nil {
+		return err
+	}
+
+	phrase, err := mnemonic.EncodePrivateKey(sk)
+	if err != nil {
+		return err
+	}
+
+	// The parameter set is not part of the phrase, thus remind the user to store it
+	fmt.Fprintf(os.Stderr, "Store the phrase together with the parameter set %d\n", sk.Mayo().SecurityLevel())
+	return writeSecretOutput(arguments.Out, []byte(phrase+"\n"))
+}
+
+func keyRestore(arguments *flags.KeyRestoreArguments) error {
+	// Initialize MAYO
+	m, err := mayo.InitMayo(arguments.ParameterSet)
+	if err != nil {
+		return err
+	}
+
+	phrase, err := readInput(arguments.In)
+	if err != nil {
+		return err
+	}
+	sk, err := mnemonic.DecodePrivateKey(m, string(phrase))
+	if err != nil {
+		return err
+	}
+
+	// Write the key files
+	if err = writePublicKey(arguments.Out+publicKeyExtension, sk.Public().(*mayo.PublicKey)); err != nil {
+		return err
+	}
+	return writePrivateKey(arguments.Out+secretKeyExtension, sk, arguments.Plaintext)
+}
+
+func keySplit(arguments *flags.KeySplitArguments) error {
+	sk, err := readPrivateKey(arguments.Key)
+	if err != nil {
+		return err
+	}
+
+	shares, err := shamir.SplitPrivateKey(nil, sk, arguments.Threshold, arguments.Shares)
+	if err != nil {
+		return err
+	}
+
+	// Every share is as sensitive as a secret key, thus only readable by the owner
+	keyID := keyIDText(sk.Public().(*mayo.PublicKey))
+	for _, share := range shares {
+		path := fmt.Sprintf("%s.%d%s", arguments.Out, share.Index, shareExtension)
+		encoded := slices.Concat(keyID, shamir.EncodeSharePEM(share, sk.Mayo().SecurityLevel()))
+		if err = writeSecretOutput(path, encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func keyCombine(arguments *flags.KeyCombineArguments) error {
+	pk, err := readPublicKey(arguments.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	shares := make([]shamir.Share, len(arguments.Shares))
+	for i, path := range arguments.Shares {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var securityLevel int
+		if shares[i], securityLevel, err = shamir.DecodeSharePEM(content); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if securityLevel != pk.Mayo().SecurityLevel() {
+			return fmt.Errorf("%s: share of parameter set %d does not match the public key", path, securityLevel)
+		}
+	}
+
+	sk, err := shamir.CombinePrivateKey(pk, shares)
+	if err != nil {
+		return err
+	}
+	return writePrivateKey(arguments.Out+secretKeyExtension, sk, arguments.Plaintext)
+}
+
+func keyCheck(arguments *flags.KeyCheckArguments) error {
+	pk, err := readPublicKey(arguments.PublicKey)
+	if err != nil {
+		return err
+	}
+	m := pk.Mayo()
+	if err = m.ValidatePublicKey(pk.Bytes()); err != nil {
+		return err
+	}
+
+	if arguments.Key == "" {
+		fmt.Fprintln(os.Stderr, "Public key has the length of parameter set", m.SecurityLevel())
+		return nil
+	}
+
+	sk, err := readPrivateKey(arguments.Key)
+	if err != nil {
+		return err
+	}
+	if sk.Mayo().SecurityLevel() != m.SecurityLevel() {
+		return fmt.Errorf("%w: the secret key is of parameter set %d, the public key of %d",
+			mayo.ErrParameterSetMismatch, sk.Mayo().SecurityLevel(), m.SecurityLevel())
+	}
+	if err = m.CheckKeyPair(pk.Bytes(), sk.Bytes()); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(os.Stderr, "Key pair is consistent")
+	return nil
+}
+
+func keyFingerprint(arguments *flags.KeyFingerprintArguments) error {
+	pk, err := readPublicKey(arguments.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	fingerprint := pk.Fingerprint()
+	switch arguments.Format {
+	case "hex":
+		fmt.Println(fingerprint.Hex())
+	case "base32":
+		fmt.Println(fingerprint.Base32())
+	case "randomart":
+		fmt.Print(fingerprint.Randomart(pk.Mayo().SecurityLevel()))
+	case "keyid":
+		fmt.Println(fingerprint.KeyID())
+	}
+	return nil
+}
+
+func benchmark(arguments *flags.BenchmarkArguments) error {
+	path, err := mayo.Benchmark(arguments.ParameterSet, arguments.AmountBenchmarkingSamples)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(fmt.Sprintf("Benchmarking done, see /%s for more information", path))
+	return nil
+}
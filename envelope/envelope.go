@@ -0,0 +1,214 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha3"
+	_ "crypto/sha512"
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+)
+
+// An envelope describes the key or signature it holds, such that it can be parsed without knowing the parameter set
+// in advance. It consists of a header followed by the body, and is laid out as follows:
+//
+//	magic "MAYO" (4) || version (1) || type (1) || security level (1) || flags (1) || key ID (8) ||
+//	[context length (1) || context, if FlagContext] || [hash ID (1), if FlagPrehash] || body
+//
+// The body is cpk for public keys, csk for private keys, and sig for detached signatures or sig || M otherwise. The
+// key ID is the one of the public key, see mayo.KeyID. Flags are only allowed for signatures.
+
+const (
+	magic    = "MAYO"
+	version1 = 1
+
+	// headerLength is the length of the fixed part of the header
+	headerLength = len(magic) + 4 + mayo.KeyIDSize
+)
+
+// Type is the type of object held by an envelope
+type Type byte
+
+const (
+	TypePublicKey  Type = 1
+	TypePrivateKey Type = 2
+	TypeSignature  Type = 3
+)
+
+// Flags describe how the signature of an envelope was computed
+type Flags byte
+
+const (
+	// FlagDetached is set if the envelope holds the signature without the message
+	FlagDetached Flags = 1 << iota
+
+	// FlagContext is set if the signature was computed with a context string, see mayo.SignWithContext
+	FlagContext
+
+	// FlagPrehash is set if the signature was computed with HashMAYO on the digest of the message, see
+	// mayo.SignHashed
+	FlagPrehash
+
+	knownFlags = FlagDetached | FlagContext | FlagPrehash
+)
+
+var (
+	// ErrMalformedEnvelope is returned when data is not an envelope, or is truncated
+	ErrMalformedEnvelope = errors.New("envelope: malformed envelope")
+
+	// ErrUnsupportedVersion is returned when the version of an envelope is not known
+	ErrUnsupportedVersion = errors.New("envelope: unsupported version")
+
+	// ErrUnexpectedType is returned when an envelope holds another type of object than the one parsed
+	ErrUnexpectedType = errors.New("envelope: unexpected type")
+
+	// ErrKeyIDMismatch is returned when the key ID of an envelope does not match the key
+	ErrKeyIDMismatch = errors.New("envelope: key ID mismatch")
+)
+
+// hashIDs maps the hash functions supported by HashMAYO to their ID in an envelope
+var hashIDs = map[crypto.Hash]byte{
+	crypto.SHA256:   1,
+	crypto.SHA384:   2,
+	crypto.SHA512:   3,
+	crypto.SHA3_256: 4,
+	crypto.SHA3_384: 5,
+	crypto.SHA3_512: 6,
+}
+
+// header is the parsed header of an envelope
+type header struct {
+	objectType Type
+	mayo       *mayo.Mayo
+	flags      Flags
+	keyID      mayo.KeyID
+	context    []byte
+	hash       crypto.Hash
+}
+
+// IsEnvelope reports whether data starts with the magic of an envelope
+func IsEnvelope(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(magic))
+}
+
+// MarshalPublicKey returns the envelope of the public key
+func MarshalPublicKey(pk *mayo.PublicKey) []byte {
+	h := &header{objectType: TypePublicKey, mayo: pk.Mayo(), keyID: pk.KeyID()}
+	return append(h.marshal(), pk.Bytes()...)
+}
+
+// ParsePublicKey parses the envelope of a public key, returning the key bound to the parameter set of the envelope.
+// Will instead return ErrKeyIDMismatch, if the key ID of the envelope is not the one of the key.
+func ParsePublicKey(data []byte) (*mayo.PublicKey, error) {
+	h, body, err := parseHeader(data, TypePublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := h.mayo.NewPublicKey(body)
+	if err != nil {
+		return nil, err
+	}
+	if pk.KeyID() != h.keyID {
+		return nil, ErrKeyIDMismatch
+	}
+	return pk, nil
+}
+
+// MarshalPrivateKey returns the envelope of the private key, which holds the key ID of its public key
+func MarshalPrivateKey(sk *mayo.PrivateKey) []byte {
+	h := &header{objectType: TypePrivateKey, mayo: sk.Mayo(), keyID: sk.Public().(*mayo.PublicKey).KeyID()}
+	return append(h.marshal(), sk.Bytes()...)
+}
+
+// ParsePrivateKey parses the envelope of a private key, returning the key bound to the parameter set of the envelope.
+// Will instead return ErrKeyIDMismatch, if the key ID of the envelope is not the one of the public key of the key.
+func ParsePrivateKey(data []byte) (*mayo.PrivateKey, error) {
+	h, body, err := parseHeader(data, TypePrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sk, err := h.mayo.NewPrivateKey(body)
+	if err != nil {
+		return nil, err
+	}
+	if sk.Public().(*mayo.PublicKey).KeyID() != h.keyID {
+		return nil, ErrKeyIDMismatch
+	}
+	return sk, nil
+}
+
+// marshal encodes the header, where the flags are derived from the context and hash function of a signature
+func (h *header) marshal() []byte {
+	flags := h.flags
+	if h.context != nil {
+		flags |= FlagContext
+	}
+	if h.hash != crypto.Hash(0) {
+		flags |= FlagPrehash
+	}
+
+	encoded := make([]byte, 0, headerLength+1+len(h.context)+1)
+	encoded = append(encoded, magic...)
+	encoded = append(encoded, version1, byte(h.objectType), byte(h.mayo.SecurityLevel()), byte(flags))
+	encoded = append(encoded, h.keyID[:]...)
+	if flags&FlagContext != 0 {
+		encoded = append(encoded, byte(len(h.context)))
+		encoded = append(encoded, h.context...)
+	}
+	if flags&FlagPrehash != 0 {
+		encoded = append(encoded, hashIDs[h.hash])
+	}
+	return encoded
+}
+
+// parseHeader parses the header of an envelope holding an object of objectType, and returns it with the body
+func parseHeader(data []byte, objectType Type) (*header, []byte, error) {
+	if len(data) < headerLength || !IsEnvelope(data) {
+		return nil, nil, ErrMalformedEnvelope
+	}
+	if data[len(magic)] != version1 {
+		return nil, nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, data[len(magic)])
+	}
+	if Type(data[len(magic)+1]) != objectType {
+		return nil, nil, fmt.Errorf("%w %d, expected %d", ErrUnexpectedType, data[len(magic)+1], objectType)
+	}
+
+	m, err := mayo.InitMayo(int(data[len(magic)+2]))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h := &header{objectType: objectType, mayo: m, flags: Flags(data[len(magic)+3])}
+	copy(h.keyID[:], data[len(magic)+4:headerLength])
+	if h.flags&^knownFlags != 0 || (objectType != TypeSignature && h.flags != 0) {
+		return nil, nil, fmt.Errorf("%w: unknown flags %#x", ErrMalformedEnvelope, byte(h.flags))
+	}
+
+	rest := data[headerLength:]
+	if h.flags&FlagContext != 0 {
+		if len(rest) < 1 || len(rest) < 1+int(rest[0]) {
+			return nil, nil, ErrMalformedEnvelope
+		}
+		h.context = bytes.Clone(rest[1 : 1+int(rest[0])])
+		rest = rest[1+int(rest[0]):]
+	}
+	if h.flags&FlagPrehash != 0 {
+		if len(rest) < 1 {
+			return nil, nil, ErrMalformedEnvelope
+		}
+		for hash, id := range hashIDs {
+			if id == rest[0] {
+				h.hash = hash
+			}
+		}
+		if h.hash == crypto.Hash(0) {
+			return nil, nil, fmt.Errorf("%w: unknown hash ID %d", mayo.ErrUnsupportedHash, rest[0])
+		}
+		rest = rest[1:]
+	}
+	return h, rest, nil
+}
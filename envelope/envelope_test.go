@@ -0,0 +1,188 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"mayo-go/mayo"
+	"testing"
+)
+
+func generateKey(t *testing.T, securityLevel int) (*mayo.PublicKey, *mayo.PrivateKey) {
+	m, err := mayo.InitMayo(securityLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk, sk
+}
+
+func TestKeyEnvelopesSelectParameterSet(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		pk, sk := generateKey(t, securityLevel)
+
+		parsedPk, err := ParsePublicKey(MarshalPublicKey(pk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsedPk.Equal(pk) {
+			t.Error("Public key does not match for security level", securityLevel)
+		}
+
+		parsedSk, err := ParsePrivateKey(MarshalPrivateKey(sk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsedSk.Mayo().SecurityLevel() != securityLevel || !bytes.Equal(parsedSk.Bytes(), sk.Bytes()) {
+			t.Error("Private key does not match for security level", securityLevel)
+		}
+
+		if _, err = ParsePrivateKey(MarshalPublicKey(pk)); !errors.Is(err, ErrUnexpectedType) {
+			t.Error("Expected ErrUnexpectedType, got:", err)
+		}
+	}
+}
+
+func TestKeyEnvelopeRejectsMismatches(t *testing.T) {
+	pk, sk := generateKey(t, 1)
+
+	// A key ID of another key is detected
+	encoded := MarshalPrivateKey(sk)
+	encoded[len(magic)+4] ^= 1
+	if _, err := ParsePrivateKey(encoded); !errors.Is(err, ErrKeyIDMismatch) {
+		t.Error("Expected ErrKeyIDMismatch, got:", err)
+	}
+
+	// Levels 1 and 2 share the length of csk, but the key ID binds the parameter set
+	encoded = MarshalPrivateKey(sk)
+	encoded[len(magic)+2] = 2
+	if _, err := ParsePrivateKey(encoded); !errors.Is(err, ErrKeyIDMismatch) {
+		t.Error("Expected ErrKeyIDMismatch for another parameter set, got:", err)
+	}
+
+	encoded = MarshalPublicKey(pk)
+	encoded[len(magic)+2] = 3
+	if _, err := ParsePublicKey(encoded); !errors.Is(err, mayo.ErrInvalidPublicKey) {
+		t.Error("Expected ErrInvalidPublicKey for another parameter set, got:", err)
+	}
+
+	encoded = MarshalPublicKey(pk)
+	encoded[len(magic)] = 2
+	if _, err := ParsePublicKey(encoded); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Error("Expected ErrUnsupportedVersion, got:", err)
+	}
+
+	encoded = MarshalPublicKey(pk)
+	encoded[len(magic)+3] = byte(FlagDetached)
+	if _, err := ParsePublicKey(encoded); !errors.Is(err, ErrMalformedEnvelope) {
+		t.Error("Expected ErrMalformedEnvelope for flags of a key, got:", err)
+	}
+
+	if _, err := ParsePublicKey(pk.Bytes()); !errors.Is(err, ErrMalformedEnvelope) {
+		t.Error("Expected ErrMalformedEnvelope for a bare key, got:", err)
+	}
+}
+
+func TestSignatureEnvelopes(t *testing.T) {
+	message := []byte("message in an envelope")
+	options := []*Options{
+		nil,
+		{Detached: true},
+		{Context: []byte("context")},
+		{Detached: true, Context: []byte{}},
+		{Hash: crypto.SHA256},
+		{Detached: true, Context: []byte("context"), Hash: crypto.SHA3_512},
+	}
+
+	// Every parameter set is selected from the envelope, and every option is tested with the fastest parameter set
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		pk, sk := generateKey(t, securityLevel)
+
+		for _, opts := range options {
+			if securityLevel != 2 && opts != nil {
+				continue
+			}
+
+			encoded, err := Sign(sk, message, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			detached := opts != nil && opts.Detached
+			var given []byte
+			if detached {
+				given = message
+			}
+			verified, err := Verify(pk, encoded, given)
+			if err != nil {
+				t.Fatal("Expected valid signature for security level", securityLevel, "and options", opts, "got:", err)
+			}
+			if !bytes.Equal(verified, message) {
+				t.Error("Verified message does not match")
+			}
+
+			s, err := ParseSignature(encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Mayo.SecurityLevel() != securityLevel || s.KeyID != pk.KeyID() || (s.Message == nil) != detached {
+				t.Error("Signature envelope not parsed correctly", s)
+			}
+			if _, err = s.Verify(pk, []byte("other message")); err == nil {
+				t.Error("Expected error for another message")
+			}
+		}
+	}
+}
+
+func TestSignatureEnvelopeRejectsMismatches(t *testing.T) {
+	pk, sk := generateKey(t, 2)
+	otherPk, _ := generateKey(t, 2)
+	level1Pk, _ := generateKey(t, 1)
+
+	encoded, err := Sign(sk, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Verify(otherPk, encoded, nil); !errors.Is(err, ErrKeyIDMismatch) {
+		t.Error("Expected ErrKeyIDMismatch, got:", err)
+	}
+	if _, err = Verify(level1Pk, encoded, nil); !errors.Is(err, mayo.ErrParameterSetMismatch) {
+		t.Error("Expected ErrParameterSetMismatch, got:", err)
+	}
+	if _, err = Verify(pk, encoded, []byte("message")); err == nil {
+		t.Error("Expected error for a message given with an attached signature")
+	}
+
+	// Changing the parameter set changes the expected length of the signature
+	tampered := bytes.Clone(encoded)
+	tampered[len(magic)+2] = 5
+	if _, err = Verify(pk, tampered, nil); err == nil {
+		t.Error("Expected error for another parameter set")
+	}
+
+	// Removing the context flag changes what is verified
+	contextual, err := Sign(sk, []byte("message"), &Options{Context: []byte("context")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered = append(bytes.Clone(contextual[:headerLength]), contextual[headerLength+1+len("context"):]...)
+	tampered[len(magic)+3] &^= byte(FlagContext)
+	if _, err = Verify(pk, tampered, nil); !errors.Is(err, mayo.ErrInvalidSignature) {
+		t.Error("Expected ErrInvalidSignature without the context, got:", err)
+	}
+
+	if _, err = Verify(pk, contextual[:headerLength+1+len("context")+3], nil); !errors.Is(err, mayo.ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength for a truncated envelope, got:", err)
+	}
+	if _, err = Verify(pk, contextual[:headerLength+3], nil); !errors.Is(err, ErrMalformedEnvelope) {
+		t.Error("Expected ErrMalformedEnvelope for a truncated context, got:", err)
+	}
+	if _, err = Sign(sk, []byte("message"), &Options{Hash: crypto.MD5}); !errors.Is(err, mayo.ErrUnsupportedHash) {
+		t.Error("Expected ErrUnsupportedHash, got:", err)
+	}
+}
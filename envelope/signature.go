@@ -0,0 +1,174 @@
+package envelope
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+)
+
+// Options are the options of Sign
+type Options struct {
+	// Detached omits the message from the envelope
+	Detached bool
+
+	// Context is the context string of at most mayo.MaxContextLength bytes, or nil for none
+	Context []byte
+
+	// Hash is the hash function the message is hashed with for HashMAYO, or zero to sign the message itself
+	Hash crypto.Hash
+}
+
+// Signature is a parsed signature envelope
+type Signature struct {
+	// Mayo is the parameter set of the signature
+	Mayo *mayo.Mayo
+
+	// KeyID is the key ID of the public key of the signer
+	KeyID mayo.KeyID
+
+	// Context and Hash are the context string and hash function of the signature, see Options
+	Context []byte
+	Hash    crypto.Hash
+
+	// Signature is the signature sig, and Message is the message if the envelope is not detached, or nil otherwise
+	Signature []byte
+	Message   []byte
+}
+
+// Sign signs message with the private key, and returns the signature envelope. If opts.Hash is set, the message is
+// hashed with it and signed with HashMAYO. opts may be nil, which signs the message itself and includes it.
+func Sign(sk *mayo.PrivateKey, message []byte, opts *Options) ([]byte, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	signed := message
+	if opts.Hash != crypto.Hash(0) {
+		if _, ok := hashIDs[opts.Hash]; !ok || !opts.Hash.Available() {
+			return nil, mayo.ErrUnsupportedHash
+		}
+		h := opts.Hash.New()
+		h.Write(message)
+		signed = h.Sum(nil)
+	}
+
+	sig, err := sk.Sign(nil, signed, &mayo.SignerOpts{Hash: opts.Hash, Context: opts.Context})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Signature{
+		Mayo:      sk.Mayo(),
+		KeyID:     sk.Public().(*mayo.PublicKey).KeyID(),
+		Context:   opts.Context,
+		Hash:      opts.Hash,
+		Signature: sig,
+	}
+	if !opts.Detached {
+		// An empty message is still attached, as a nil message marks the envelope as detached
+		s.Message = append([]byte{}, message...)
+	}
+	return s.Marshal()
+}
+
+// Marshal returns the envelope of the signature, which is detached if s.Message is nil
+func (s *Signature) Marshal() ([]byte, error) {
+	if len(s.Signature) != s.Mayo.SignatureSize() {
+		return nil, mayo.ErrInvalidSignatureLength
+	}
+	if len(s.Context) > mayo.MaxContextLength {
+		return nil, mayo.ErrContextTooLong
+	}
+	if _, ok := hashIDs[s.Hash]; !ok && s.Hash != crypto.Hash(0) {
+		return nil, mayo.ErrUnsupportedHash
+	}
+
+	h := &header{objectType: TypeSignature, mayo: s.Mayo, keyID: s.KeyID, context: s.Context, hash: s.Hash}
+	if s.Message == nil {
+		h.flags = FlagDetached
+	}
+
+	encoded := append(h.marshal(), s.Signature...)
+	return append(encoded, s.Message...), nil
+}
+
+// ParseSignature parses a signature envelope, where the parameter set is selected by the envelope. Will instead return
+// mayo.ErrInvalidSignatureLength, if the signature does not have the length of the parameter set.
+func ParseSignature(data []byte) (*Signature, error) {
+	h, body, err := parseHeader(data, TypeSignature)
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytes := h.mayo.SignatureSize()
+	if len(body) < sigBytes || (h.flags&FlagDetached != 0 && len(body) != sigBytes) {
+		return nil, mayo.ErrInvalidSignatureLength
+	}
+
+	s := &Signature{
+		Mayo:      h.mayo,
+		KeyID:     h.keyID,
+		Context:   h.context,
+		Hash:      h.hash,
+		Signature: body[:sigBytes],
+	}
+	if h.flags&FlagDetached == 0 {
+		s.Message = body[sigBytes:]
+	}
+	return s, nil
+}
+
+// Verify verifies the signature with the public key, on message if the envelope is detached, or on the message of the
+// envelope otherwise, in which case message must be nil. It returns the message that was verified. Will instead return
+// mayo.ErrParameterSetMismatch or ErrKeyIDMismatch, if the signature is not by a key of the parameter set or key ID
+// of pk, or mayo.ErrInvalidSignature, if the signature is not valid.
+func (s *Signature) Verify(pk *mayo.PublicKey, message []byte) ([]byte, error) {
+	if s.Message != nil {
+		if message != nil {
+			return nil, errors.New("envelope: the message is given, but the signature is not detached")
+		}
+		message = s.Message
+	}
+
+	if err := s.CheckPublicKey(pk); err != nil {
+		return nil, err
+	}
+
+	signed := message
+	if s.Hash != crypto.Hash(0) {
+		if !s.Hash.Available() {
+			return nil, mayo.ErrUnsupportedHash
+		}
+		h := s.Hash.New()
+		h.Write(message)
+		signed = h.Sum(nil)
+	}
+
+	if !mayo.VerifyWithOptions(pk, signed, s.Signature, &mayo.SignerOpts{Hash: s.Hash, Context: s.Context}) {
+		return nil, mayo.ErrInvalidSignature
+	}
+	return message, nil
+}
+
+// CheckPublicKey checks that the signature is of the parameter set and key ID of the public key, without verifying it.
+// Will instead return mayo.ErrParameterSetMismatch or ErrKeyIDMismatch.
+func (s *Signature) CheckPublicKey(pk *mayo.PublicKey) error {
+	if pk.Mayo().SecurityLevel() != s.Mayo.SecurityLevel() {
+		return fmt.Errorf("%w: the signature is of parameter set %d, the public key of %d",
+			mayo.ErrParameterSetMismatch, s.Mayo.SecurityLevel(), pk.Mayo().SecurityLevel())
+	}
+	if pk.KeyID() != s.KeyID {
+		return fmt.Errorf("%w: the signature is by key %s, not %s", ErrKeyIDMismatch, s.KeyID, pk.KeyID())
+	}
+	return nil
+}
+
+// Verify parses the signature envelope data and verifies it with the public key as in Signature.Verify
+func Verify(pk *mayo.PublicKey, data, message []byte) ([]byte, error) {
+	s, err := ParseSignature(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.Verify(pk, message)
+}
@@ -1,9 +1,13 @@
 package field
 
 import (
+	"errors"
 	"fmt"
 )
 
+// ErrDimensionMismatch is returned (wrapped) by the Try variants, when the dimensions of the operands do not match
+var ErrDimensionMismatch = errors.New("field: dimension mismatch")
+
 type Field struct {
 	mulTable [][]byte
 	invTable []byte
@@ -18,10 +22,16 @@ func InitField() *Field {
 	}
 }
 
+// VectorTransposedMatrixMul multiplies the transposed vector with the matrix. Panics if the dimensions do not match
 func (f *Field) VectorTransposedMatrixMul(vec []byte, matrix [][]byte) []byte {
+	return mustCompute(f.TryVectorTransposedMatrixMul(vec, matrix))
+}
+
+// TryVectorTransposedMatrixMul is VectorTransposedMatrixMul, but returns an error if the dimensions do not match
+func (f *Field) TryVectorTransposedMatrixMul(vec []byte, matrix [][]byte) ([]byte, error) {
 	cols := len(matrix)
 	if cols == 0 || len(vec) != len(matrix) {
-		panic("Vector length must match matrix row count")
+		return nil, fmt.Errorf("%w: vector length must match matrix row count", ErrDimensionMismatch)
 	}
 
 	rows := len(matrix[0])
@@ -35,15 +45,20 @@ func (f *Field) VectorTransposedMatrixMul(vec []byte, matrix [][]byte) []byte {
 		result[i] = sum
 	}
 
-	return result
+	return result, nil
 }
 
 // MatrixVectorMul Takes a matrix and vector, here we assume that the output of this multiplication will be a
-// vector, since this is the case in MAYO.
+// vector, since this is the case in MAYO. Panics if the dimensions do not match
 func (f *Field) MatrixVectorMul(matrix [][]byte, vec []byte) []byte {
+	return mustCompute(f.TryMatrixVectorMul(matrix, vec))
+}
+
+// TryMatrixVectorMul is MatrixVectorMul, but returns an error if the dimensions do not match
+func (f *Field) TryMatrixVectorMul(matrix [][]byte, vec []byte) ([]byte, error) {
 	rows := len(matrix)
 	if rows == 0 || len(vec) != len(matrix[0]) {
-		panic("Vector length must match matrix column count")
+		return nil, fmt.Errorf("%w: vector length must match matrix column count", ErrDimensionMismatch)
 	}
 
 	cols := len(matrix[0])
@@ -57,16 +72,25 @@ func (f *Field) MatrixVectorMul(matrix [][]byte, vec []byte) []byte {
 		result[i] = sum
 	}
 
-	return result
+	return result, nil
 }
 
-// MultiplyMatrices multiplies two matrices
+// MultiplyMatrices multiplies two matrices. Panics if the dimensions do not match
 func (f *Field) MultiplyMatrices(A, B [][]byte) [][]byte {
+	return mustCompute(f.TryMultiplyMatrices(A, B))
+}
+
+// TryMultiplyMatrices is MultiplyMatrices, but returns an error if the dimensions do not match
+func (f *Field) TryMultiplyMatrices(A, B [][]byte) ([][]byte, error) {
+	if len(A) == 0 || len(B) == 0 {
+		return nil, fmt.Errorf("%w: cannot multiply empty matrices", ErrDimensionMismatch)
+	}
+
 	rowsA, colsA := len(A), len(A[0])
 	rowsB, colsB := len(B), len(B[0])
 
 	if colsA != rowsB {
-		panic(fmt.Sprintf("Cannot multiply matrices colsA: '%d', rowsB: '%d'", colsA, rowsB))
+		return nil, fmt.Errorf("%w: cannot multiply matrices colsA: '%d', rowsB: '%d'", ErrDimensionMismatch, colsA, rowsB)
 	}
 
 	C := make([][]byte, rowsA)
@@ -79,24 +103,37 @@ func (f *Field) MultiplyMatrices(A, B [][]byte) [][]byte {
 		}
 	}
 
-	return C
+	return C, nil
 }
 
-// AddMatrices adds two matrices element-wise
+// AddMatrices adds two matrices element-wise. Panics if the dimensions do not match
 func AddMatrices(A, B [][]byte) [][]byte {
+	return mustCompute(TryAddMatrices(A, B))
+}
+
+// TryAddMatrices is AddMatrices, but returns an error if the dimensions do not match
+func TryAddMatrices(A, B [][]byte) ([][]byte, error) {
+	if len(A) == 0 || len(B) == 0 {
+		return nil, fmt.Errorf("%w: cannot add empty matrices", ErrDimensionMismatch)
+	}
+
 	rowsA, colsA := len(A), len(A[0])
 	rowsB, colsB := len(B), len(B[0])
 
 	if rowsA != rowsB || colsA != colsB {
-		panic("Cannot add matrices")
+		return nil, fmt.Errorf("%w: cannot add matrices", ErrDimensionMismatch)
 	}
 
 	C := make([][]byte, rowsA)
 	for i := range C {
-		C[i] = AddVec(A[i], B[i])
+		row, err := TryAddVec(A[i], B[i])
+		if err != nil {
+			return nil, err
+		}
+		C[i] = row
 	}
 
-	return C
+	return C, nil
 }
 
 // MultiplyVecConstant multiplies a vector by a constant element-wise
@@ -118,9 +155,15 @@ func (f *Field) Gf16Inv(a byte) byte {
 	return f.invTable[a]
 }
 
+// VecInnerProduct computes the inner product of two vectors. Panics if the vectors have different lengths
 func (f *Field) VecInnerProduct(vec1Transposed []byte, vec2 []byte) byte {
+	return mustCompute(f.TryVecInnerProduct(vec1Transposed, vec2))
+}
+
+// TryVecInnerProduct is VecInnerProduct, but returns an error if the vectors have different lengths
+func (f *Field) TryVecInnerProduct(vec1Transposed []byte, vec2 []byte) (byte, error) {
 	if len(vec1Transposed) != len(vec2) {
-		panic("Vectors must have the same length")
+		return 0, fmt.Errorf("%w: vectors must have the same length", ErrDimensionMismatch)
 	}
 
 	var result byte = 0
@@ -128,7 +171,7 @@ func (f *Field) VecInnerProduct(vec1Transposed []byte, vec2 []byte) byte {
 		result ^= f.Gf16Mul(vec1Transposed[i], vec2[i])
 	}
 
-	return result
+	return result, nil
 }
 
 func gf16Mul(a, b byte) byte {
@@ -168,9 +211,15 @@ func generateMulAndInvTable() ([][]byte, []byte) {
 	return mulTable, invTable
 }
 
+// AddVec adds two vectors element-wise. Panics if the vectors have different lengths
 func AddVec(A, B []byte) []byte {
+	return mustCompute(TryAddVec(A, B))
+}
+
+// TryAddVec is AddVec, but returns an error if the vectors have different lengths
+func TryAddVec(A, B []byte) ([]byte, error) {
 	if len(A) != len(B) {
-		panic("Cannot add vectors of different lengths")
+		return nil, fmt.Errorf("%w: cannot add vectors of different lengths", ErrDimensionMismatch)
 	}
 
 	C := make([]byte, len(A))
@@ -178,5 +227,13 @@ func AddVec(A, B []byte) []byte {
 		C[i] = A[i] ^ B[i]
 	}
 
-	return C
+	return C, nil
+}
+
+// mustCompute returns the result of a Try variant, and panics if it returned an error
+func mustCompute[T any](result T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return result
 }
@@ -2,6 +2,7 @@ package field
 
 import (
 	"bytes"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -61,3 +62,36 @@ func TestAddSubVectorWorksAsExpectedInTheField(t *testing.T) {
 		t.Error("Addition and subtraction failed")
 	}
 }
+
+func TestTryVariantsReturnErrorOnDimensionMismatch(t *testing.T) {
+	field := InitField()
+
+	A := [][]byte{
+		{1, 2, 3},
+		{4, 5, 6},
+	}
+
+	if _, err := field.TryMultiplyMatrices(A, A); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when multiplying, got:", err)
+	}
+
+	if _, err := TryAddMatrices(A, A[:1]); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when adding matrices, got:", err)
+	}
+
+	if _, err := field.TryMatrixVectorMul(A, []byte{1, 2}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when multiplying matrix and vector, got:", err)
+	}
+
+	if _, err := field.TryVectorTransposedMatrixMul([]byte{1, 2, 3}, A); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when multiplying vector and matrix, got:", err)
+	}
+
+	if _, err := field.TryVecInnerProduct([]byte{1, 2}, []byte{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when computing inner product, got:", err)
+	}
+
+	if _, err := TryAddVec([]byte{1, 2}, []byte{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Error("Expected ErrDimensionMismatch when adding vectors, got:", err)
+	}
+}
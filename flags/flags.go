@@ -1,23 +1,420 @@
 package flags
 
-import "flag"
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
 
-type ApplicationArguments struct {
+// StandardStream is the path which denotes stdin or stdout
+const StandardStream = "-"
+
+// plaintextUsage is the usage of the flag to write secret key files unencrypted
+const plaintextUsage = "Write the secret key unencrypted, instead of encrypted with a passphrase"
+
+// ErrHelp is returned when the usage was requested
+var ErrHelp = flag.ErrHelp
+
+// Command is implemented by the arguments of every subcommand
+type Command interface {
+	// Name returns the name of the subcommand
+	Name() string
+}
+
+type KeyGenArguments struct {
+	ParameterSet int
+	Out          string
+	Plaintext    bool
+}
+
+type SignArguments struct {
+	Key, In, Out       string
+	Attached, Envelope bool
+}
+
+type VerifyArguments struct {
+	PublicKey, In, Signature, Out string
+	Attached                      bool
+}
+
+type CSRArguments struct {
+	Key, CommonName, Organization, Out string
+}
+
+type DeriveArguments struct {
+	Seed, Path   string
+	ParameterSet int
+	Out          string
+	Plaintext    bool
+}
+
+type KeyBackupArguments struct {
+	Key, Out string
+}
+
+type KeyRestoreArguments struct {
+	ParameterSet int
+	In, Out      string
+	Plaintext    bool
+}
+
+type KeySplitArguments struct {
+	Key, Out          string
+	Threshold, Shares int
+}
+
+type KeyCombineArguments struct {
+	PublicKey string
+	Shares    []string
+	Out       string
+	Plaintext bool
+}
+
+type KeyCheckArguments struct {
+	PublicKey, Key string
+}
+
+type KeyFingerprintArguments struct {
+	PublicKey, Format string
+}
+
+type BenchmarkArguments struct {
 	AmountBenchmarkingSamples, ParameterSet int
 }
 
-func GetApplicationArguments() ApplicationArguments {
-	// Creating struct with empty arguments
-	arguments := ApplicationArguments{}
+func (*KeyGenArguments) Name() string         { return "keygen" }
+func (*SignArguments) Name() string           { return "sign" }
+func (*VerifyArguments) Name() string         { return "verify" }
+func (*CSRArguments) Name() string            { return "csr" }
+func (*DeriveArguments) Name() string         { return "derive" }
+func (*KeyBackupArguments) Name() string      { return "key backup" }
+func (*KeyRestoreArguments) Name() string     { return "key restore" }
+func (*KeySplitArguments) Name() string       { return "key split" }
+func (*KeyCombineArguments) Name() string     { return "key combine" }
+func (*KeyCheckArguments) Name() string       { return "key check" }
+func (*KeyFingerprintArguments) Name() string { return "key fingerprint" }
+func (*BenchmarkArguments) Name() string      { return "benchmark" }
+
+// stringList is a flag which may be given several times, collecting every value
+type stringList []string
+
+func (list *stringList) String() string { return strings.Join(*list, ", ") }
+
+func (list *stringList) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+// subcommand describes how the arguments of a subcommand are parsed
+type subcommand struct {
+	name        string
+	description string
+	define      func(flagSet *flag.FlagSet) Command
+	validate    func(command Command) error
+}
+
+var subcommands = []subcommand{
+	{
+		name:        "keygen",
+		description: "Generate a key pair, written to <out>.pk and <out>.sk",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyGenArguments{}
+			flagSet.IntVar(&arguments.ParameterSet, "p", 0, "Decides what parameter set should be used")
+			flagSet.StringVar(&arguments.Out, "out", "", "Path prefix of the key files")
+			flagSet.BoolVar(&arguments.Plaintext, "plaintext", false, plaintextUsage)
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*KeyGenArguments)
+			if arguments.Out == "" {
+				return errors.New("the -out flag must be set")
+			}
+			return validateParameterSet(arguments.ParameterSet)
+		},
+	},
+	{
+		name:        "sign",
+		description: "Sign a message, outputting a detached signature or the signed message sig || M",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &SignArguments{}
+			flagSet.StringVar(&arguments.Key, "key", "", "Path of the secret key file")
+			flagSet.StringVar(&arguments.In, "in", StandardStream, "Path of the message, '-' for stdin")
+			flagSet.StringVar(&arguments.Out, "out", StandardStream, "Path of the signature, '-' for stdout")
+			flagSet.BoolVar(&arguments.Attached, "attached", false, "Output the signed message sig || M")
+			flagSet.BoolVar(&arguments.Envelope, "envelope", false,
+				"Output a signature envelope, which records the parameter set and key ID")
+			return arguments
+		},
+		validate: func(command Command) error {
+			if command.(*SignArguments).Key == "" {
+				return errors.New("the -key flag must be set")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "verify",
+		description: "Verify a detached signature, or a signed message sig || M",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &VerifyArguments{}
+			flagSet.StringVar(&arguments.PublicKey, "pub", "", "Path of the public key file")
+			flagSet.StringVar(&arguments.In, "in", StandardStream,
+				"Path of the message, or the signed message if -attached is set, '-' for stdin")
+			flagSet.StringVar(&arguments.Signature, "sig", "", "Path of the detached signature")
+			flagSet.StringVar(&arguments.Out, "out", "",
+				"Path to write the message of a valid signed message to, '-' for stdout")
+			flagSet.BoolVar(&arguments.Attached, "attached", false, "Verify the signed message sig || M")
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*VerifyArguments)
+			if arguments.PublicKey == "" {
+				return errors.New("the -pub flag must be set")
+			} else if !arguments.Attached && arguments.Out != "" {
+				return errors.New("the -out flag can only be used with -attached")
+			} else if arguments.Attached && arguments.Signature != "" {
+				return errors.New("the -sig flag cannot be used with -attached")
+			} else if !arguments.Attached && arguments.Signature == "" {
+				return errors.New("the -sig flag must be set, unless -attached is set")
+			} else if arguments.In == StandardStream && arguments.Signature == StandardStream {
+				return errors.New("the message and signature cannot both be read from stdin")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "csr",
+		description: "Create a PKCS #10 certificate signing request for a secret key, self-signed by the key",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &CSRArguments{}
+			flagSet.StringVar(&arguments.Key, "key", "", "Path of the secret key file")
+			flagSet.StringVar(&arguments.CommonName, "cn", "", "Common name of the subject")
+			flagSet.StringVar(&arguments.Organization, "o", "", "Organization of the subject")
+			flagSet.StringVar(&arguments.Out, "out", StandardStream, "Path of the PEM encoded request, '-' for stdout")
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*CSRArguments)
+			if arguments.Key == "" {
+				return errors.New("the -key flag must be set")
+			} else if arguments.CommonName == "" {
+				return errors.New("the -cn flag must be set")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "derive",
+		description: "Derive the key of a path from a hex encoded master seed, printing the public key cpk in hex",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &DeriveArguments{}
+			flagSet.StringVar(&arguments.Seed, "seed", "", "Path of the file holding the hex encoded master seed")
+			flagSet.StringVar(&arguments.Path, "path", "", "Derivation path, such as m/devices/1234")
+			flagSet.IntVar(&arguments.ParameterSet, "p", 0, "Decides what parameter set should be used")
+			flagSet.StringVar(&arguments.Out, "out", "", "Path prefix to also write the key files <out>.pk and <out>.sk")
+			flagSet.BoolVar(&arguments.Plaintext, "plaintext", false, plaintextUsage)
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*DeriveArguments)
+			if arguments.Seed == "" {
+				return errors.New("the -seed flag must be set")
+			} else if arguments.Path == "" {
+				return errors.New("the -path flag must be set")
+			}
+			return validateParameterSet(arguments.ParameterSet)
+		},
+	},
+	{
+		name:        "key backup",
+		description: "Write the secret key as a phrase of words, which must be stored with the parameter set",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyBackupArguments{}
+			flagSet.StringVar(&arguments.Key, "key", "", "Path of the secret key file")
+			flagSet.StringVar(&arguments.Out, "out", StandardStream, "Path of the phrase, '-' for stdout")
+			return arguments
+		},
+		validate: func(command Command) error {
+			if command.(*KeyBackupArguments).Key == "" {
+				return errors.New("the -key flag must be set")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "key restore",
+		description: "Restore a key pair from a phrase, written to <out>.pk and <out>.sk",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyRestoreArguments{}
+			flagSet.IntVar(&arguments.ParameterSet, "p", 0, "Parameter set of the backed up key")
+			flagSet.StringVar(&arguments.In, "in", StandardStream, "Path of the phrase, '-' for stdin")
+			flagSet.StringVar(&arguments.Out, "out", "", "Path prefix of the key files")
+			flagSet.BoolVar(&arguments.Plaintext, "plaintext", false, plaintextUsage)
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*KeyRestoreArguments)
+			if arguments.Out == "" {
+				return errors.New("the -out flag must be set")
+			}
+			return validateParameterSet(arguments.ParameterSet)
+		},
+	},
+	{
+		name:        "key split",
+		description: "Split the secret key into shares, written to <out>.<index>.share, of which -t recover the key",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeySplitArguments{}
+			flagSet.StringVar(&arguments.Key, "key", "", "Path of the secret key file")
+			flagSet.IntVar(&arguments.Threshold, "t", 0, "Amount of shares needed to recover the key")
+			flagSet.IntVar(&arguments.Shares, "n", 0, "Amount of shares")
+			flagSet.StringVar(&arguments.Out, "out", "", "Path prefix of the share files")
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*KeySplitArguments)
+			if arguments.Key == "" {
+				return errors.New("the -key flag must be set")
+			} else if arguments.Out == "" {
+				return errors.New("the -out flag must be set")
+			} else if arguments.Threshold < 2 || arguments.Threshold > arguments.Shares {
+				return errors.New("the -t flag must be at least 2 and at most the -n flag")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "key combine",
+		description: "Recover a secret key from shares, checked against the public key and written to <out>.sk",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyCombineArguments{}
+			flagSet.StringVar(&arguments.PublicKey, "pub", "", "Path of the public key file")
+			flagSet.Var((*stringList)(&arguments.Shares), "share", "Path of a share file, given once for every share")
+			flagSet.StringVar(&arguments.Out, "out", "", "Path prefix of the secret key file")
+			flagSet.BoolVar(&arguments.Plaintext, "plaintext", false, plaintextUsage)
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*KeyCombineArguments)
+			if arguments.PublicKey == "" {
+				return errors.New("the -pub flag must be set")
+			} else if len(arguments.Shares) == 0 {
+				return errors.New("the -share flag must be set")
+			} else if arguments.Out == "" {
+				return errors.New("the -out flag must be set")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "key check",
+		description: "Check the length of the public key, and that it belongs to the secret key if -key is set",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyCheckArguments{}
+			flagSet.StringVar(&arguments.PublicKey, "pub", "", "Path of the public key file")
+			flagSet.StringVar(&arguments.Key, "key", "", "Path of the secret key file")
+			return arguments
+		},
+		validate: func(command Command) error {
+			if command.(*KeyCheckArguments).PublicKey == "" {
+				return errors.New("the -pub flag must be set")
+			}
+			return nil
+		},
+	},
+	{
+		name:        "key fingerprint",
+		description: "Print the fingerprint of the public key as hex, base32, randomart, or its key ID",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &KeyFingerprintArguments{}
+			flagSet.StringVar(&arguments.PublicKey, "pub", "", "Path of the public key file")
+			flagSet.StringVar(&arguments.Format, "format", "hex",
+				"Format of the fingerprint: hex, base32, randomart, or keyid for the key ID")
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*KeyFingerprintArguments)
+			if arguments.PublicKey == "" {
+				return errors.New("the -pub flag must be set")
+			} else if !slices.Contains([]string{"hex", "base32", "randomart", "keyid"}, arguments.Format) {
+				return fmt.Errorf("unknown fingerprint format '%s'", arguments.Format)
+			}
+			return nil
+		},
+	},
+	{
+		name:        "benchmark",
+		description: "Benchmark the implementation, writing the results to the results directory",
+		define: func(flagSet *flag.FlagSet) Command {
+			arguments := &BenchmarkArguments{}
+			flagSet.IntVar(&arguments.ParameterSet, "p", 0, "Decides what parameter set should be used")
+			flagSet.IntVar(&arguments.AmountBenchmarkingSamples, "b", 100, "The amount of samples")
+			return arguments
+		},
+		validate: func(command Command) error {
+			arguments := command.(*BenchmarkArguments)
+			if arguments.AmountBenchmarkingSamples <= 0 {
+				return errors.New("the -b flag must be positive")
+			}
+			return validateParameterSet(arguments.ParameterSet)
+		},
+	},
+}
+
+// validateParameterSet checks that the -p flag is the security level of a parameter set
+func validateParameterSet(parameterSet int) error {
+	if !slices.Contains([]int{1, 2, 3, 5}, parameterSet) {
+		return errors.New("the -p flag must be 1, 2, 3, or 5")
+	}
+	return nil
+}
+
+// GetApplicationArguments parses the subcommand and its flags from args, which excludes the program name. Usage is
+// written to output, if the arguments are invalid or help is requested.
+func GetApplicationArguments(args []string, output io.Writer) (Command, error) {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		writeUsage(output)
+		if len(args) == 0 {
+			return nil, errors.New("no subcommand given")
+		}
+		return nil, ErrHelp
+	}
 
-	// Getting arguments from flags
-	flag.IntVar(&arguments.AmountBenchmarkingSamples, "b", 0,
-		"Decides if the implementation should be benchmarked, and the amount of samples")
-	flag.IntVar(&arguments.ParameterSet, "p", 0,
-		"Decides what parameter set should be used")
+	for _, entry := range subcommands {
+		// The name of a subcommand may consist of several words, such as 'key backup'
+		words := strings.Fields(entry.name)
+		if len(args) < len(words) || !slices.Equal(args[:len(words)], words) {
+			continue
+		}
 
-	// Parsing flags
-	flag.Parse()
+		flagSet := flag.NewFlagSet(entry.name, flag.ContinueOnError)
+		flagSet.SetOutput(output)
+		command := entry.define(flagSet)
+		if err := flagSet.Parse(args[len(words):]); err != nil {
+			return nil, err
+		}
+		if flagSet.NArg() > 0 {
+			return nil, fmt.Errorf("unexpected argument '%s'", flagSet.Arg(0))
+		}
+		if err := entry.validate(command); err != nil {
+			return nil, err
+		}
+		return command, nil
+	}
+
+	writeUsage(output)
+	return nil, fmt.Errorf("unknown subcommand '%s'", args[0])
+}
 
-	return arguments
+func writeUsage(output io.Writer) {
+	var usage strings.Builder
+	usage.WriteString("Usage: mayo <subcommand> [flags]\n\nSubcommands:\n")
+	for _, entry := range subcommands {
+		usage.WriteString(fmt.Sprintf("  %-16s %s\n", entry.name, entry.description))
+	}
+	usage.WriteString("\nRun 'mayo <subcommand> -h' to list the flags of a subcommand.\n")
+	_, _ = io.WriteString(output, usage.String())
 }
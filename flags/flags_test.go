@@ -0,0 +1,104 @@
+package flags
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestGetApplicationArgumentsParsesSubcommand(t *testing.T) {
+	command, err := GetApplicationArguments([]string{"sign", "-key", "key.sk", "-in", "file", "-attached"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arguments, ok := command.(*SignArguments)
+	if !ok {
+		t.Fatal("Expected sign arguments, got:", command)
+	}
+	if arguments.Key != "key.sk" || arguments.In != "file" || arguments.Out != StandardStream || !arguments.Attached {
+		t.Error("Arguments not parsed correctly", arguments)
+	}
+}
+
+func TestGetApplicationArgumentsParsesNestedSubcommand(t *testing.T) {
+	command, err := GetApplicationArguments([]string{"key", "restore", "-p", "3", "-out", "key"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arguments, ok := command.(*KeyRestoreArguments)
+	if !ok {
+		t.Fatal("Expected key restore arguments, got:", command)
+	}
+	if arguments.ParameterSet != 3 || arguments.In != StandardStream || arguments.Out != "key" {
+		t.Error("Arguments not parsed correctly", arguments)
+	}
+}
+
+func TestGetApplicationArgumentsCollectsRepeatedFlag(t *testing.T) {
+	command, err := GetApplicationArguments([]string{"key", "combine", "-pub", "key.pk", "-share", "key.1.share",
+		"-share", "key.3.share", "-out", "key"}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arguments, ok := command.(*KeyCombineArguments)
+	if !ok {
+		t.Fatal("Expected key combine arguments, got:", command)
+	}
+	if !slices.Equal(arguments.Shares, []string{"key.1.share", "key.3.share"}) {
+		t.Error("Shares not collected correctly", arguments.Shares)
+	}
+}
+
+func TestGetApplicationArgumentsRejectsInvalidArguments(t *testing.T) {
+	invalidArguments := [][]string{
+		{},
+		{"unknown"},
+		{"keygen", "-p", "1"},
+		{"keygen", "-out", "key"},
+		{"keygen", "-p", "4", "-out", "key"},
+		{"benchmark", "-p", "0"},
+		{"derive", "-seed", "master.seed", "-path", "m/1", "-p", "6"},
+		{"key", "restore", "-out", "key"},
+		{"sign", "-in", "file"},
+		{"verify", "-pub", "key.pk", "-in", "file"},
+		{"verify", "-pub", "key.pk", "-sig", "file.sig", "-attached"},
+		{"verify", "-pub", "key.pk", "-sig", "-"},
+		{"verify", "-pub", "key.pk", "-sig", "file.sig", "-out", "file"},
+		{"keygen", "-out", "key", "extra"},
+		{"csr", "-key", "key.sk"},
+		{"csr", "-cn", "example.com"},
+		{"derive", "-seed", "master.seed"},
+		{"derive", "-path", "m/devices/1234"},
+		{"key"},
+		{"key", "unknown"},
+		{"key", "backup"},
+		{"key", "restore", "-p", "2"},
+		{"key", "split", "-key", "key.sk", "-t", "3", "-n", "2", "-out", "key"},
+		{"key", "split", "-key", "key.sk", "-t", "1", "-n", "3", "-out", "key"},
+		{"key", "split", "-t", "2", "-n", "3", "-out", "key"},
+		{"key", "combine", "-pub", "key.pk", "-out", "key"},
+		{"key", "combine", "-share", "key.1.share", "-out", "key"},
+		{"key", "check", "-key", "key.sk"},
+		{"key", "fingerprint", "-format", "hex"},
+		{"key", "fingerprint", "-pub", "key.pk", "-format", "base64"},
+	}
+
+	for _, args := range invalidArguments {
+		if _, err := GetApplicationArguments(args, io.Discard); err == nil {
+			t.Error("Expected error for arguments", args)
+		}
+	}
+}
+
+func TestGetApplicationArgumentsHelp(t *testing.T) {
+	if _, err := GetApplicationArguments([]string{"help"}, io.Discard); !errors.Is(err, ErrHelp) {
+		t.Error("Expected ErrHelp, got:", err)
+	}
+	if _, err := GetApplicationArguments([]string{"keygen", "-h"}, io.Discard); !errors.Is(err, ErrHelp) {
+		t.Error("Expected ErrHelp, got:", err)
+	}
+}
@@ -3,6 +3,8 @@ module mayo-go
 go 1.24.0
 
 require (
-	golang.org/x/crypto v0.33.0 // indirect
-	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/crypto v0.33.0
+	golang.org/x/term v0.29.0
 )
+
+require golang.org/x/sys v0.30.0 // indirect
@@ -0,0 +1,125 @@
+// Package hd derives MAYO keys of every parameter set from a master seed along a derivation path, such as
+// m/devices/1234. The derivation is built on SHAKE256, where every step is domain separated and every variable length
+// input is prefixed with its length:
+//
+//	node(m)        = SHAKE256("MAYO-HD master" || len(seed) || seed, 64)
+//	node(p/label)  = SHAKE256("MAYO-HD child" || node(p) || len(label) || label, 64)
+//	seedSk(p, set) = SHAKE256("MAYO-HD seed" || securityLevel || node(p), skSeedBytes)
+//
+// The length of the seed is encoded as two big-endian bytes, and the length of a label and the security level as one
+// byte. A node can only be derived from its parent node, thus a public key never reveals anything about its siblings.
+package hd
+
+import (
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+	"mayo-go/rand"
+	"strings"
+)
+
+const (
+	// MinSeedLength and MaxSeedLength bound the length of the master seed
+	MinSeedLength = 16
+	MaxSeedLength = 1024
+
+	// MaxLabelLength is the maximum length of a label in a derivation path
+	MaxLabelLength = 255
+
+	// nodeLength is the length of the secret of a node
+	nodeLength = 64
+
+	// pathSeparator separates the labels of a derivation path, which starts with masterLabel
+	pathSeparator = "/"
+	masterLabel   = "m"
+)
+
+var (
+	domainMaster = []byte("MAYO-HD master")
+	domainChild  = []byte("MAYO-HD child")
+	domainSeed   = []byte("MAYO-HD seed")
+)
+
+// ErrInvalidPath is returned when a derivation path cannot be parsed
+var ErrInvalidPath = errors.New("hd: invalid derivation path")
+
+// Node is a node in the derivation tree, from which the keys of every parameter set and its children can be derived
+type Node struct {
+	secret [nodeLength]byte
+	path   string
+}
+
+// NewMaster returns the root node m of the derivation tree of seed, which must be between MinSeedLength and
+// MaxSeedLength bytes
+func NewMaster(seed []byte) (*Node, error) {
+	if len(seed) < MinSeedLength || len(seed) > MaxSeedLength {
+		return nil, fmt.Errorf("hd: master seed must be between %d and %d bytes", MinSeedLength, MaxSeedLength)
+	}
+
+	node := &Node{path: masterLabel}
+	length := []byte{byte(len(seed) >> 8), byte(len(seed))}
+	copy(node.secret[:], rand.Shake256(nodeLength, domainMaster, length, seed))
+	return node, nil
+}
+
+// Child returns the child of n with the given label, which must be non-empty, at most MaxLabelLength bytes, and must
+// not contain the path separator
+func (n *Node) Child(label string) (*Node, error) {
+	if label == "" || len(label) > MaxLabelLength || strings.Contains(label, pathSeparator) {
+		return nil, fmt.Errorf("%w: label '%s'", ErrInvalidPath, label)
+	}
+
+	child := &Node{path: n.path + pathSeparator + label}
+	copy(child.secret[:], rand.Shake256(nodeLength, domainChild, n.secret[:], []byte{byte(len(label))}, []byte(label)))
+	return child, nil
+}
+
+// Derive returns the descendant of the master node n given by path, such as m/devices/1234
+func (n *Node) Derive(path string) (*Node, error) {
+	if n.path != masterLabel {
+		return nil, fmt.Errorf("%w: can only derive a path from the master node", ErrInvalidPath)
+	}
+
+	labels, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node := n
+	for _, label := range labels {
+		if node, err = node.Child(label); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// Path returns the derivation path of n
+func (n *Node) Path() string {
+	return n.path
+}
+
+// Seed returns the seed seedSk of the parameter set derived from n, which is the compact secret key csk
+func (n *Node) Seed(m *mayo.Mayo) []byte {
+	return rand.Shake256(m.SeedSize(), domainSeed, []byte{byte(m.SecurityLevel())}, n.secret[:])
+}
+
+// GenerateKey returns the key pair of the parameter set derived from n
+func (n *Node) GenerateKey(m *mayo.Mayo) (*mayo.PublicKey, *mayo.PrivateKey, error) {
+	return m.GenerateKeyFromSeed(n.Seed(m))
+}
+
+// ParsePath parses a derivation path of the form m/label/.../label, and returns its labels
+func ParsePath(path string) ([]string, error) {
+	labels := strings.Split(path, pathSeparator)
+	if labels[0] != masterLabel {
+		return nil, fmt.Errorf("%w: '%s' does not start with '%s'", ErrInvalidPath, path, masterLabel)
+	}
+
+	for _, label := range labels[1:] {
+		if label == "" || len(label) > MaxLabelLength {
+			return nil, fmt.Errorf("%w: '%s' has an empty or too long label", ErrInvalidPath, path)
+		}
+	}
+	return labels[1:], nil
+}
@@ -0,0 +1,123 @@
+package hd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mayo-go/mayo"
+	"testing"
+)
+
+// vectors are the test vectors of the derivation, for the master seed 00 01 02 ... 1f. The public key is given by the
+// SHA-256 hash of cpk.
+var vectors = []struct {
+	path          string
+	securityLevel int
+	seedSk        string
+	cpkHash       string
+}{
+	{"m", 1, "7ad3a0a4af96e7b61610b6b725b3faf7d88f189749ab0a1e", "35a7fcf7825c246ec65f86e0a4fa60c601b21d35ecd2b41ed6cea994f6cf1ae6"},
+	{"m/devices/1234", 1, "41262593ac6c74985807ba978462ba7e6ecde47a676bc290", "54c3d4e75981332ebbd677c6ee17a1c04bfe008074911a9836d93d062a75a07e"},
+	{"m/devices/1234", 2, "0de643de075ba528ee09f12f8ecf40c6741d7cdeb23d696b", "84e963d9d3f9b4504c0925145bba7094355b50abe61dad90b7cd1fc2cc313904"},
+	{"m/devices/1234", 3, "2207b3b1b9ee32d6450600becdf65109a5c26a22af07dd49087916b3defba859", "a092dc75f8d196abb21714efa674c8fc766491442649ba7b6df64d1a57b9ec5e"},
+	{"m/devices/1234", 5, "b2dab4161f58855e5e084b946d3049f1488e7f6f4da03f7c87e45d396cca13b11ebd8a773dea7eee", "c16eee6cdd2c8c4f43508f2f6ab1e5a866f84decce6a31ca6c8bca658f7fc7a8"},
+	{"m/devices/1235", 2, "6895555a09134a849fe62a458578c98d9c1e11a3fabafd8f", "94f7b493ed3372533ba637b734fb142942f474f8a89c2e2ed5c0f51cddac3f56"},
+}
+
+func testMaster(t *testing.T) *Node {
+	t.Helper()
+
+	seed := make([]byte, 32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	master, err := NewMaster(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return master
+}
+
+func TestVectors(t *testing.T) {
+	master := testMaster(t)
+
+	for _, vector := range vectors {
+		m, err := mayo.InitMayo(vector.securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		node, err := master.Derive(vector.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node.Path() != vector.path {
+			t.Error("Path of the node should be", vector.path, "was", node.Path())
+		}
+
+		if seedSk := hex.EncodeToString(node.Seed(m)); seedSk != vector.seedSk {
+			t.Error("Wrong seed for", vector.path, vector.securityLevel, "got:", seedSk)
+		}
+
+		pk, sk, err := node.GenerateKey(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cpkHash := sha256.Sum256(pk.Bytes())
+		if hex.EncodeToString(cpkHash[:]) != vector.cpkHash {
+			t.Error("Wrong public key for", vector.path, vector.securityLevel)
+		}
+		if !bytes.Equal(sk.Bytes(), node.Seed(m)) {
+			t.Error("Secret key should be the derived seed")
+		}
+	}
+}
+
+func TestChildMatchesDerive(t *testing.T) {
+	master := testMaster(t)
+
+	devices, err := master.Child("devices")
+	if err != nil {
+		t.Fatal(err)
+	}
+	child, err := devices.Child("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	derived, err := master.Derive("m/devices/1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if child.secret != derived.secret || child.Path() != derived.Path() {
+		t.Error("Deriving a path should equal deriving its children one by one")
+	}
+
+	// Labels are length prefixed, thus concatenating labels results in another node
+	concatenated, err := master.Derive("m/devices1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if concatenated.secret == derived.secret {
+		t.Error("Different paths should result in different nodes")
+	}
+}
+
+func TestInvalidPathsAndSeeds(t *testing.T) {
+	master := testMaster(t)
+
+	for _, path := range []string{"", "devices/1234", "m/", "m//1234", "n/devices", "/m/devices"} {
+		if _, err := master.Derive(path); !errors.Is(err, ErrInvalidPath) {
+			t.Error("Expected ErrInvalidPath for", path, "got:", err)
+		}
+	}
+
+	child, _ := master.Child("devices")
+	if _, err := child.Derive("m/1234"); !errors.Is(err, ErrInvalidPath) {
+		t.Error("Expected ErrInvalidPath when deriving a path from a child, got:", err)
+	}
+
+	if _, err := NewMaster(make([]byte, MinSeedLength-1)); err == nil {
+		t.Error("Expected error for a short master seed")
+	}
+}
@@ -8,18 +8,22 @@ import (
 )
 
 func TestKat1(t *testing.T) {
+	t.Parallel()
 	CheckMayoKat("kat_files/PQCsignKAT_24_MAYO_1.rsp", 1, t)
 }
 
 func TestKat2(t *testing.T) {
+	t.Parallel()
 	CheckMayoKat("kat_files/PQCsignKAT_24_MAYO_2.rsp", 2, t)
 }
 
 func TestKat3(t *testing.T) {
+	t.Parallel()
 	CheckMayoKat("kat_files/PQCsignKAT_32_MAYO_3.rsp", 3, t)
 }
 
 func TestKat5(t *testing.T) {
+	t.Parallel()
 	CheckMayoKat("kat_files/PQCsignKAT_40_MAYO_5.rsp", 5, t)
 }
 
@@ -31,9 +35,14 @@ func CheckMayoKat(fileName string, securityLevel int, t *testing.T) {
 	}
 
 	for _, katData := range katDataList {
-		rand.InitRandomness(katData.seed, make([]byte, 48), 256)
+		// The KATs are generated with the NIST CTR-DRBG, thus it must be used instead of the default source
+		drbg, err := rand.NewCTRDRBG(katData.seed, make([]byte, 48))
+		if err != nil {
+			t.Fatal(err)
+		}
+		seeded := mayo.WithRandom(drbg)
 
-		epk, esk, _ := mayo.CompactKeyGen()
+		epk, esk, _ := seeded.CompactKeyGen()
 
 		if !bytes.Equal(epk, katData.pk) {
 			t.Error("Generated PK and KAT PK are not equal", katData.count, epk, katData.pk)
@@ -43,7 +52,10 @@ func CheckMayoKat(fileName string, securityLevel int, t *testing.T) {
 			t.Error("Generated SK and KAT SK are not equal", katData.count, esk, katData.sk)
 		}
 
-		sig := mayo.APISign(katData.message, esk)
+		sig, err := seeded.APISign(katData.message, esk)
+		if err != nil {
+			t.Error(err)
+		}
 		if !bytes.Equal(sig, katData.signature) {
 			t.Error("Generated signature and KAT signature are not equal", katData.count, sig, katData.signature)
 		}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io"
+	"mayo-go/envelope"
+	"mayo-go/flags"
+	"mayo-go/keystore"
+	"mayo-go/mayo"
+	"mayo-go/pki"
+	"os"
+)
+
+const (
+	publicKeyExtension = ".pk"
+	secretKeyExtension = ".sk"
+	shareExtension     = ".share"
+)
+
+// keyIDText returns the explanatory text written before the PEM block of a key file, which identifies the key. Text
+// outside of PEM blocks is ignored by parsers, see RFC 7468.
+func keyIDText(pk *mayo.PublicKey) []byte {
+	return []byte(fmt.Sprintf("Key-ID: %s\n", pk.KeyID()))
+}
+
+// writePublicKey writes the public key to a PEM file holding a SubjectPublicKeyInfo
+func writePublicKey(path string, pk *mayo.PublicKey) error {
+	encoded, err := pki.EncodePublicKeyPEM(pk)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(keyIDText(pk), encoded...), 0644)
+}
+
+// writePrivateKey writes the private key to a PEM file only readable by the owner. The key is encrypted with a
+// passphrase, unless plaintext is set, in which case the file holds a PKCS #8 private key.
+func writePrivateKey(path string, sk *mayo.PrivateKey, plaintext bool) error {
+	var encoded []byte
+	if plaintext {
+		var err error
+		if encoded, err = pki.EncodePrivateKeyPEM(sk, true); err != nil {
+			return err
+		}
+	} else {
+		passphrase, err := readPassphrase(true)
+		if err != nil {
+			return err
+		}
+		if encoded, err = keystore.EncodePEM(sk, passphrase, nil); err != nil {
+			return err
+		}
+	}
+	return writeSecretOutput(path, append(keyIDText(sk.Public().(*mayo.PublicKey)), encoded...))
+}
+
+// readPublicKey reads a public key file, which is either PEM, a public key envelope, or a raw compact public key cpk
+// whose parameter set is detected from its length
+func readPublicKey(path string) (*mayo.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// A raw cpk may begin with the magic of an envelope, thus it is only an envelope if it can be parsed
+	if pk, err := envelope.ParsePublicKey(content); err == nil {
+		return pk, nil
+	}
+	if block, _ := pem.Decode(content); block == nil {
+		m, err := mayo.DetectParameterSet(content)
+		if err != nil {
+			return nil, err
+		}
+		return m.NewPublicKey(content)
+	}
+	return pki.DecodePublicKeyPEM(content)
+}
+
+// readPrivateKey reads a private key file, which is either a private key envelope, or PEM, which is decrypted with a
+// passphrase if it is encrypted
+func readPrivateKey(path string) (*mayo.PrivateKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if envelope.IsEnvelope(content) {
+		return envelope.ParsePrivateKey(content)
+	}
+	if keystore.IsEncryptedPEM(content) {
+		passphrase, err := readPassphrase(false)
+		if err != nil {
+			return nil, err
+		}
+		return keystore.DecodePEM(content, passphrase)
+	}
+	return pki.DecodePrivateKeyPEM(content)
+}
+
+// readInput reads the file at path, or stdin if path is flags.StandardStream
+func readInput(path string) ([]byte, error) {
+	if path == flags.StandardStream {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// copyInput copies the file at path, or stdin if path is flags.StandardStream, to w without reading it into memory
+func copyInput(w io.Writer, path string) error {
+	if path == flags.StandardStream {
+		_, err := io.Copy(w, os.Stdin)
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// writeOutput writes data to the file at path, or stdout if path is flags.StandardStream
+func writeOutput(path string, data []byte) error {
+	if path == flags.StandardStream {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// writeSecretOutput writes secret data, such as a backup phrase, to the file at path only readable by the owner, or
+// stdout if path is flags.StandardStream. An existing file is made only readable by the owner before it is written.
+func writeSecretOutput(path string, data []byte) error {
+	if path == flags.StandardStream {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if err = file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
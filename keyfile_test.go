@@ -0,0 +1,42 @@
+package main
+
+import (
+	"mayo-go/flags"
+	"mayo-go/mayo"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestKeyBackupIsOnlyReadableByOwner(t *testing.T) {
+	m, _ := mayo.InitMayo(1)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key"+secretKeyExtension)
+	if err = writePrivateKey(keyPath, sk, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// An existing file readable by everyone must not stay readable after the phrase is written to it
+	backupPath := filepath.Join(dir, "key.phrase")
+	if err = os.WriteFile(backupPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = keyBackup(&flags.KeyBackupArguments{Key: keyPath, Out: backupPath}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{keyPath, backupPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("Expected mode 0600 for %s, got %o", filepath.Base(path), info.Mode().Perm())
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package keystore
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// An encrypted key consists of a header followed by the compact secret key csk, which is encrypted with
+// ChaCha20-Poly1305 under a key derived from the passphrase with scrypt. The header is authenticated as associated
+// data, and is laid out as follows, where integers are big-endian:
+//
+//	magic "MAYOKEY\x00" (8) || version (1) || security level (1) || scrypt log2(N) (1) || scrypt r (4) ||
+//	scrypt p (4) || salt (16) || nonce (12)
+//
+// Version 1 is the only version, which fixes scrypt and ChaCha20-Poly1305. A new version is needed to change either.
+
+// PEMType is the type of PEM blocks holding an encrypted key
+const PEMType = "MAYO ENCRYPTED PRIVATE KEY"
+
+const (
+	magic        = "MAYOKEY\x00"
+	version1     = 1
+	saltLength   = 16
+	headerLength = len(magic) + 3 + 8 + saltLength + chacha20poly1305.NonceSize
+	keyLength    = chacha20poly1305.KeySize
+
+	// maxLogN, maxMemory and maxP bound the scrypt parameters of a decrypted key, such that a crafted header cannot
+	// exhaust the resources of the machine. The memory of scrypt is about 128 * r * N bytes, while its time is about
+	// p times that of its memory, thus bounding the memory and p also bounds the time.
+	maxLogN   = 24
+	maxMemory = 1 << 32
+	maxP      = 16
+)
+
+var (
+	// ErrIncorrectPassphrase is returned when the key cannot be decrypted, because the passphrase is incorrect or the
+	// encrypted key was modified
+	ErrIncorrectPassphrase = errors.New("keystore: incorrect passphrase or corrupted key")
+
+	// ErrUnsupportedVersion is returned when the version of an encrypted key is not known
+	ErrUnsupportedVersion = errors.New("keystore: unsupported version")
+)
+
+// ScryptParameters are the parameters of scrypt, where N is 2^LogN
+type ScryptParameters struct {
+	LogN uint8
+	R, P uint32
+}
+
+// DefaultScryptParameters are the parameters used when none are given, which take about a second on current machines
+var DefaultScryptParameters = ScryptParameters{LogN: 18, R: 8, P: 1}
+
+// Encrypt encrypts the private key with passphrase, using the scrypt parameters, or DefaultScryptParameters if params
+// is nil
+func Encrypt(sk *mayo.PrivateKey, passphrase []byte, params *ScryptParameters) ([]byte, error) {
+	if params == nil {
+		params = &DefaultScryptParameters
+	}
+	if err := params.check(); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, headerLength)
+	header = append(header, magic...)
+	header = append(header, version1, byte(sk.Mayo().SecurityLevel()), params.LogN)
+	header = binary.BigEndian.AppendUint32(header, params.R)
+	header = binary.BigEndian.AppendUint32(header, params.P)
+
+	random := make([]byte, saltLength+chacha20poly1305.NonceSize)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	header = append(header, random...)
+
+	aead, err := deriveAEAD(passphrase, header, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := header[headerLength-chacha20poly1305.NonceSize:]
+	return aead.Seal(header, nonce, sk.Bytes(), header), nil
+}
+
+// Decrypt decrypts an encrypted private key with passphrase, returning the key bound to the parameter set recorded in
+// the header
+func Decrypt(data, passphrase []byte) (*mayo.PrivateKey, error) {
+	if len(data) < headerLength || string(data[:len(magic)]) != magic {
+		return nil, errors.New("keystore: not an encrypted key")
+	}
+
+	header, ciphertext := data[:headerLength], data[headerLength:]
+	if header[len(magic)] != version1 {
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedVersion, header[len(magic)])
+	}
+
+	m, err := mayo.InitMayo(int(header[len(magic)+1]))
+	if err != nil {
+		return nil, err
+	}
+
+	params := &ScryptParameters{
+		LogN: header[len(magic)+2],
+		R:    binary.BigEndian.Uint32(header[len(magic)+3:]),
+		P:    binary.BigEndian.Uint32(header[len(magic)+7:]),
+	}
+	if err = params.check(); err != nil {
+		return nil, err
+	}
+
+	aead, err := deriveAEAD(passphrase, header, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := header[headerLength-chacha20poly1305.NonceSize:]
+	csk, err := aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, ErrIncorrectPassphrase
+	}
+	return m.NewPrivateKey(csk)
+}
+
+// EncodePEM encrypts the private key as in Encrypt, and encodes it as a PEM block
+func EncodePEM(sk *mayo.PrivateKey, passphrase []byte, params *ScryptParameters) ([]byte, error) {
+	encrypted, err := Encrypt(sk, passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PEMType, Bytes: encrypted}), nil
+}
+
+// DecodePEM decodes the first PEM block of data, which must hold an encrypted key, and decrypts it as in Decrypt
+func DecodePEM(data, passphrase []byte) (*mayo.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != PEMType {
+		return nil, errors.New("keystore: no encrypted key PEM block found")
+	}
+	return Decrypt(block.Bytes, passphrase)
+}
+
+// IsEncryptedPEM reports whether the first PEM block of data holds an encrypted key
+func IsEncryptedPEM(data []byte) bool {
+	block, _ := pem.Decode(data)
+	return block != nil && block.Type == PEMType
+}
+
+// deriveAEAD derives the key from passphrase and the salt in header, and returns the AEAD using it
+func deriveAEAD(passphrase, header []byte, params *ScryptParameters) (cipher.AEAD, error) {
+	salt := header[headerLength-chacha20poly1305.NonceSize-saltLength : headerLength-chacha20poly1305.NonceSize]
+	key, err := scrypt.Key(passphrase, salt, 1<<params.LogN, int(params.R), int(params.P), keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.New(key)
+}
+
+// check checks that the parameters are accepted by scrypt, and within the bounds of what is decrypted
+func (params *ScryptParameters) check() error {
+	if params.LogN < 1 || params.LogN > maxLogN || params.R == 0 || params.P == 0 {
+		return errors.New("keystore: invalid scrypt parameters")
+	}
+	if uint64(128)*uint64(params.R)*(uint64(1)<<params.LogN+uint64(params.P)) > maxMemory {
+		return errors.New("keystore: scrypt parameters exceed the memory limit")
+	}
+	if params.P > maxP {
+		return fmt.Errorf("keystore: scrypt parallelization p must be at most %d", maxP)
+	}
+	return nil
+}
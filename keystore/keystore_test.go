@@ -0,0 +1,114 @@
+package keystore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"mayo-go/mayo"
+	"testing"
+)
+
+// testParameters are scrypt parameters which are fast enough for tests, but far too weak for real keys
+var testParameters = &ScryptParameters{LogN: 10, R: 8, P: 1}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		m, err := mayo.InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, sk, err := m.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodePEM(sk, []byte("correct horse"), testParameters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !IsEncryptedPEM(encoded) {
+			t.Error("Encoded key should be detected as encrypted")
+		}
+		if bytes.Contains(encoded, sk.Bytes()) {
+			t.Error("Encoded key should not contain the secret key")
+		}
+
+		decrypted, err := DecodePEM(encoded, []byte("correct horse"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decrypted.Mayo().SecurityLevel() != securityLevel || !bytes.Equal(decrypted.Bytes(), sk.Bytes()) {
+			t.Error("Decrypted key does not match for security level", securityLevel)
+		}
+	}
+}
+
+func TestDecryptRejectsWrongPassphraseAndTampering(t *testing.T) {
+	m, _ := mayo.InitMayo(1)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := Encrypt(sk, []byte("correct horse"), testParameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Decrypt(encrypted, []byte("wrong horse")); !errors.Is(err, ErrIncorrectPassphrase) {
+		t.Error("Expected ErrIncorrectPassphrase, got:", err)
+	}
+
+	// The header is authenticated, thus changing the security level is detected
+	tampered := bytes.Clone(encrypted)
+	tampered[len(magic)+1] = 2
+	if _, err = Decrypt(tampered, []byte("correct horse")); !errors.Is(err, ErrIncorrectPassphrase) {
+		t.Error("Expected ErrIncorrectPassphrase for a modified header, got:", err)
+	}
+
+	tampered = bytes.Clone(encrypted)
+	tampered[len(tampered)-1] ^= 1
+	if _, err = Decrypt(tampered, []byte("correct horse")); !errors.Is(err, ErrIncorrectPassphrase) {
+		t.Error("Expected ErrIncorrectPassphrase for a modified ciphertext, got:", err)
+	}
+
+	tampered = bytes.Clone(encrypted)
+	tampered[len(magic)] = 2
+	if _, err = Decrypt(tampered, []byte("correct horse")); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Error("Expected ErrUnsupportedVersion, got:", err)
+	}
+}
+
+func TestDecryptRejectsExcessiveParameters(t *testing.T) {
+	m, _ := mayo.InitMayo(1)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Encrypt(sk, []byte("passphrase"), &ScryptParameters{LogN: 30, R: 8, P: 1}); err == nil {
+		t.Error("Expected error for excessive scrypt parameters")
+	}
+
+	encrypted, err := Encrypt(sk, []byte("passphrase"), testParameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted[len(magic)+2] = 40
+	if _, err = Decrypt(encrypted, []byte("passphrase")); err == nil || errors.Is(err, ErrIncorrectPassphrase) {
+		t.Error("Expected parameter error before running scrypt, got:", err)
+	}
+
+	// A small N and r with a large p passes the memory limit, but would take hours to derive
+	if _, err = Encrypt(sk, []byte("passphrase"), &ScryptParameters{LogN: 10, R: 8, P: maxP + 1}); err == nil {
+		t.Error("Expected error for excessive scrypt parallelization")
+	}
+	encrypted, err = Encrypt(sk, []byte("passphrase"), testParameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary.BigEndian.PutUint32(encrypted[len(magic)+7:], maxP+1)
+	if _, err = Decrypt(encrypted, []byte("passphrase")); err == nil || errors.Is(err, ErrIncorrectPassphrase) {
+		t.Error("Expected parameter error before running scrypt, got:", err)
+	}
+}
@@ -1,59 +1,71 @@
 package main
 
 import (
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"mayo-go/flags"
-	crypto "mayo-go/mayo"
-	"time"
+	"os"
 )
 
-func main() {
-	// Get application flags
-	arguments := flags.GetApplicationArguments()
-	securityLevel := arguments.ParameterSet
-	amountOfBenchmarkSamples := arguments.AmountBenchmarkingSamples
-
-	if amountOfBenchmarkSamples > 0 {
-		path, err := crypto.Benchmark(securityLevel, amountOfBenchmarkSamples)
+// Exit codes of the application
+const (
+	exitSuccess          = 0
+	exitInvalidSignature = 1
+	exitUsage            = 2
+	exitError            = 3
+)
 
-		if err != nil {
-			fmt.Println("Got error while benchmarking: ", err)
-		}
+// errInvalidSignature is returned by the verify subcommand if the signature is not valid
+var errInvalidSignature = errors.New("signature is not valid")
 
-		fmt.Println(fmt.Sprintf("Benchmarking done, see /%s for more information", path))
-		return
-	}
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-	// Initialize MAYO
-	message := []byte("Hello, world!")
-	mayo, err := crypto.InitMayo(2)
-	if err != nil {
-		fmt.Println(err)
-		return
+// run executes the subcommand given by args and returns the exit code
+func run(args []string) int {
+	// Get application arguments
+	command, err := flags.GetApplicationArguments(args, os.Stderr)
+	if errors.Is(err, flags.ErrHelp) {
+		return exitSuccess
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, "mayo:", err)
+		return exitUsage
 	}
 
-	// Generate the public key and secret key
-	before := time.Now()
-	cpk, csk, err := mayo.CompactKeyGen()
-	if err != nil {
-		fmt.Println(err)
-		return
+	switch arguments := command.(type) {
+	case *flags.KeyGenArguments:
+		err = keyGen(arguments)
+	case *flags.SignArguments:
+		err = sign(arguments)
+	case *flags.VerifyArguments:
+		err = verify(arguments)
+	case *flags.CSRArguments:
+		err = createCSR(arguments)
+	case *flags.DeriveArguments:
+		err = derive(arguments)
+	case *flags.KeyBackupArguments:
+		err = keyBackup(arguments)
+	case *flags.KeyRestoreArguments:
+		err = keyRestore(arguments)
+	case *flags.KeySplitArguments:
+		err = keySplit(arguments)
+	case *flags.KeyCombineArguments:
+		err = keyCombine(arguments)
+	case *flags.KeyCheckArguments:
+		err = keyCheck(arguments)
+	case *flags.KeyFingerprintArguments:
+		err = keyFingerprint(arguments)
+	case *flags.BenchmarkArguments:
+		err = benchmark(arguments)
 	}
-	fmt.Println(fmt.Sprintf("Keygen took: %dms", time.Since(before).Milliseconds()))
-
-	// Sign the message
-	before = time.Now()
-	sig := mayo.APISign(message, csk)
-	fmt.Println(fmt.Sprintf("Signing took: %dms", time.Since(before).Milliseconds()))
 
-	// Check if the signature is valid
-	before = time.Now()
-	valid, _ := mayo.APISignOpen(sig, cpk)
-	fmt.Println(fmt.Sprintf("Verification took: %dms", time.Since(before).Milliseconds()))
-	if valid == 0 {
-		fmt.Println(fmt.Sprintf("Sig: '%s' is a valid signature on the message: '%s'", hex.EncodeToString(sig), message))
-	} else {
-		fmt.Println(fmt.Sprintf("Sig: '%s' is not a valid signature on the message: '%s'", hex.EncodeToString(sig), message))
+	if errors.Is(err, errInvalidSignature) {
+		fmt.Fprintln(os.Stderr, "mayo:", err)
+		return exitInvalidSignature
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "mayo %s: %s\n", command.Name(), err)
+		return exitError
 	}
+	return exitSuccess
 }
@@ -63,7 +63,10 @@ func Benchmark(paramSet, n int) (string, error) {
 	signatures := make([][]byte, n)
 	for i := 0; i < n; i++ {
 		before := time.Now()
-		sig := mayo.APISign(message, csks[i])
+		sig, err := mayo.APISign(message, csks[i])
+		if err != nil {
+			return "", err
+		}
 		duration := time.Since(before)
 		signResults[i] = duration.Nanoseconds()
 		signatures[i] = sig
@@ -0,0 +1,33 @@
+package mayo
+
+import "crypto/subtle"
+
+// ValidatePublicKey checks that the compact public key cpk, which is seedPk followed by the upper triangular parts of
+// the P_i^3, has the length of the parameter set. Will return ErrInvalidPublicKey otherwise. Only the length is
+// checked, as m is even for every parameter set, thus the P_i^3 have no padding nibbles, and every byte string of the
+// length is the encoding of a public key. Use CheckKeyPair to check that a public key belongs to a secret key.
+func (mayo *Mayo) ValidatePublicKey(cpk []byte) error {
+	if len(cpk) != mayo.cpkBytes {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// CheckKeyPair checks that the compact secret key csk belongs to the compact public key cpk, by deriving seedPk and
+// the P_i^3 from the seed as in CompactKeyGen, and comparing them to cpk in constant time. Will return
+// ErrInvalidPublicKey or ErrInvalidPrivateKey, if a key does not have the length of the parameter set, or
+// ErrKeyPairMismatch, if the keys do not belong together.
+func (mayo *Mayo) CheckKeyPair(cpk, csk []byte) error {
+	if len(cpk) != mayo.cpkBytes {
+		return ErrInvalidPublicKey
+	}
+	if len(csk) != mayo.cskBytes {
+		return ErrInvalidPrivateKey
+	}
+
+	derived, _ := mayo.compactKeyGen(csk)
+	if subtle.ConstantTimeCompare(derived, cpk) != 1 {
+		return ErrKeyPairMismatch
+	}
+	return nil
+}
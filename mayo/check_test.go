@@ -0,0 +1,66 @@
+package mayo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCheckKeyPair(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		mayo, err := InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cpk, csk, err := mayo.CompactKeyGen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mayo.CheckKeyPair(cpk, csk); err != nil {
+			t.Error("Expected matching key pair for security level", securityLevel, "got:", err)
+		}
+
+		otherCpk, _, err := mayo.CompactKeyGen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mayo.CheckKeyPair(otherCpk, csk); !errors.Is(err, ErrKeyPairMismatch) {
+			t.Error("Expected ErrKeyPairMismatch, got:", err)
+		}
+
+		// A single changed nibble of P3 is detected
+		modified := bytes.Clone(cpk)
+		modified[len(modified)-1] ^= 0x10
+		if err = mayo.CheckKeyPair(modified, csk); !errors.Is(err, ErrKeyPairMismatch) {
+			t.Error("Expected ErrKeyPairMismatch for a modified public key, got:", err)
+		}
+
+		if err = mayo.CheckKeyPair(cpk[1:], csk); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Error("Expected ErrInvalidPublicKey, got:", err)
+		}
+		if err = mayo.CheckKeyPair(cpk, csk[1:]); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Error("Expected ErrInvalidPrivateKey, got:", err)
+		}
+	}
+}
+
+func TestValidatePublicKey(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		mayo, err := InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cpk, _, err := mayo.CompactKeyGen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = mayo.ValidatePublicKey(cpk); err != nil {
+			t.Error("Expected valid public key for security level", securityLevel, "got:", err)
+		}
+		if err = mayo.ValidatePublicKey(append(cpk, 0)); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Error("Expected ErrInvalidPublicKey for a public key that is too long, got:", err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package mayo
+
+import (
+	"crypto"
+	"mayo-go/rand"
+)
+
+// MaxContextLength is the maximum length of a context string
+const MaxContextLength = 255
+
+// contextCustomization is the cSHAKE256 customization string of the message digest of SignWithContext, which separates
+// it from the SHAKE256 digest of Sign and from HashMAYO
+const contextCustomization = "MAYO context"
+
+// SignWithContext signs the message m with the context string ctx, which binds the signature to a protocol, such that
+// it is not valid for another context. The message digest is cSHAKE256 of len(ctx) || ctx || m with the customization
+// string "MAYO context", which is computed without copying m. As Sign computes the message digest with SHAKE256, no
+// message signed by Sign results in the digest of a signature with a context, or the other way around. An empty
+// context selects the plain mode of Sign, such that the signatures are those of the known answer tests. Will instead
+// return ErrContextTooLong, if ctx is longer than MaxContextLength, or any error of SignDigest.
+func (mayo *Mayo) SignWithContext(esk, m, ctx []byte) ([]byte, error) {
+	mDigest, err := mayo.encodedDigest(m, crypto.Hash(0), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mayo.SignDigest(esk, mDigest)
+}
+
+// VerifyWithContext verifies a signature of SignWithContext on m with the context string ctx. Will instead output < 0
+// and ErrContextTooLong, if ctx is longer than MaxContextLength, or any error of VerifyDigest.
+func (mayo *Mayo) VerifyWithContext(epk, m, sig, ctx []byte) (int, error) {
+	mDigest, err := mayo.encodedDigest(m, crypto.Hash(0), ctx)
+	if err != nil {
+		return -1, err
+	}
+	return mayo.VerifyDigest(epk, mDigest, sig)
+}
+
+// encodedDigest outputs the digest of the encoded message. If hash is zero, m is the message which is encoded as in
+// SignWithContext, and otherwise m is the digest of the message which is encoded as in SignHashed.
+func (mayo *Mayo) encodedDigest(m []byte, hash crypto.Hash, ctx []byte) ([]byte, error) {
+	if len(ctx) > MaxContextLength {
+		return nil, ErrContextTooLong
+	}
+
+	if hash == crypto.Hash(0) {
+		if len(ctx) == 0 {
+			return rand.Shake256(mayo.digestBytes, m), nil
+		}
+		return rand.CShake256(mayo.digestBytes, contextCustomization, []byte{byte(len(ctx))}, ctx, m), nil
+	}
+
+	encodedOID, err := hashOID(hash, m)
+	if err != nil {
+		return nil, err
+	}
+	return rand.CShake256(mayo.digestBytes, hashMayoCustomization, []byte{byte(len(ctx))}, ctx, encodedOID, m), nil
+}
@@ -0,0 +1,138 @@
+package mayo
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"mayo-go/rand"
+	"slices"
+	"testing"
+)
+
+func TestSignWithContext(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, _ := mayo.ExpandSK(csk)
+	epk, _ := mayo.ExpandPK(cpk)
+	message := []byte("This is a message.")
+	ctx := []byte("protocol A")
+
+	sig, err := mayo.SignWithContext(esk, message, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result, err := mayo.VerifyWithContext(epk, message, sig, ctx); result != 0 || err != nil {
+		t.Error("Signature should be valid with the same context, got:", result, err)
+	}
+	if result, _ := mayo.VerifyWithContext(epk, message, sig, []byte("protocol B")); result == 0 {
+		t.Error("Signature should not be valid with another context")
+	}
+	if result, _ := mayo.Verify(epk, message, sig); result == 0 {
+		t.Error("Signature with a context should not be valid as a plain signature")
+	}
+
+	// The context is encoded with its length, thus moving bytes between the context and message changes the signature
+	if result, _ := mayo.VerifyWithContext(epk, message[1:], sig, append(ctx, message[0])); result == 0 {
+		t.Error("Signature should not be valid when the boundary between context and message is moved")
+	}
+
+	if _, err = mayo.SignWithContext(esk, message, make([]byte, MaxContextLength+1)); !errors.Is(err, ErrContextTooLong) {
+		t.Error("Expected ErrContextTooLong, got:", err)
+	}
+	if _, err = mayo.SignWithContext(esk, message, make([]byte, MaxContextLength)); err != nil {
+		t.Error("Context of MaxContextLength bytes should be accepted, got:", err)
+	}
+}
+
+func TestEmptyContextIsPlainSign(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, _ := mayo.ExpandSK(csk)
+	message := []byte("This is a message.")
+
+	seed := make([]byte, 48)
+	drbgA, _ := rand.NewCTRDRBG(seed, nil)
+	drbgB, _ := rand.NewCTRDRBG(seed, nil)
+
+	expected, err := mayo.WithRandom(drbgA).Sign(esk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := mayo.WithRandom(drbgB).SignWithContext(esk, message, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sig, expected) {
+		t.Error("Signature with an empty context should be identical to the signature of Sign")
+	}
+}
+
+func TestSignerOptsContext(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+	opts := &SignerOpts{Context: []byte("protocol A")}
+
+	sig, err := sk.Sign(nil, message, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyWithOptions(pk, message, sig, opts) {
+		t.Error("Signature should be valid with the same options")
+	}
+	if Verify(pk, message, sig) {
+		t.Error("Signature with a context should not be valid as a plain signature")
+	}
+
+	// A plain signature on the encoding of the message with the context is not a signature with the context
+	for _, encoded := range [][]byte{
+		slices.Concat([]byte{byte(len(opts.Context))}, opts.Context, message),
+		slices.Concat([]byte{0x00, byte(len(opts.Context))}, opts.Context, message),
+	} {
+		plainSig, err := sk.Sign(nil, encoded, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if VerifyWithOptions(pk, message, plainSig, opts) {
+			t.Error("Plain signature on an encoded message should not be valid with the context")
+		}
+	}
+
+	// HashMAYO with a context
+	digest := sha256.Sum256(message)
+	hashedOpts := &SignerOpts{Hash: crypto.SHA256, Context: opts.Context}
+	sig, err = sk.Sign(nil, digest[:], hashedOpts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyWithOptions(pk, digest[:], sig, hashedOpts) {
+		t.Error("HashMAYO signature should be valid with the same options")
+	}
+	if VerifyHashed(pk, crypto.SHA256, digest[:], sig) {
+		t.Error("HashMAYO signature with a context should not be valid without the context")
+	}
+}
@@ -0,0 +1,78 @@
+package mayo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// securityLevels are the security levels of the parameter sets, see InitMayo
+var securityLevels = []int{1, 2, 3, 5}
+
+// DetectParameterSet returns the parameter set whose compact public keys have the length of cpk. Will instead return
+// ErrUnknownParameterSet, if no parameter set has keys of that length.
+func DetectParameterSet(cpk []byte) (*Mayo, error) {
+	return detectParameterSet("public key", len(cpk), func(mayo *Mayo) int { return mayo.cpkBytes })
+}
+
+// DetectParameterSetFromPrivateKey returns the parameter set whose compact secret keys have the length of csk. Will
+// instead return ErrUnknownParameterSet, if no parameter set has keys of that length, or ErrAmbiguousParameterSet, as
+// for the 24 bytes of both levels 1 and 2, in which case the parameter set must be given.
+func DetectParameterSetFromPrivateKey(csk []byte) (*Mayo, error) {
+	return detectParameterSet("private key", len(csk), func(mayo *Mayo) int { return mayo.cskBytes })
+}
+
+// DetectParameterSetFromSignature returns the parameter set whose signatures have the length of sig. Will instead
+// return ErrUnknownParameterSet, if no parameter set has signatures of that length.
+func DetectParameterSetFromSignature(sig []byte) (*Mayo, error) {
+	return detectParameterSet("signature", len(sig), func(mayo *Mayo) int { return mayo.sigBytes })
+}
+
+// VerifyAuto verifies the signature sig on the message msg with the compact public key cpk, where the parameter set is
+// detected from the length of cpk, and must also be the one of the signature. Will instead return any error of
+// DetectParameterSet, ErrParameterSetMismatch, if the signature belongs to another parameter set, or
+// ErrInvalidSignature, if the signature is not valid.
+func VerifyAuto(cpk, msg, sig []byte) (*Mayo, error) {
+	mayo, err := DetectParameterSet(cpk)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sig) != mayo.sigBytes {
+		if other, err := DetectParameterSetFromSignature(sig); err == nil {
+			return nil, fmt.Errorf("%w: the public key is of parameter set %d, the signature of %d",
+				ErrParameterSetMismatch, mayo.securityLevel, other.securityLevel)
+		}
+		return nil, ErrInvalidSignatureLength
+	}
+
+	if err = mayo.VerifyDetached(cpk, msg, sig); err != nil {
+		return nil, err
+	}
+	return mayo, nil
+}
+
+// detectParameterSet returns the parameter set for which size returns length, where kind names the object in errors
+func detectParameterSet(kind string, length int, size func(*Mayo) int) (*Mayo, error) {
+	var matches []*Mayo
+	for _, securityLevel := range securityLevels {
+		// The security levels are known, thus InitMayo cannot fail
+		mayo, _ := InitMayo(securityLevel)
+		if size(mayo) == length {
+			matches = append(matches, mayo)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s of %d bytes", ErrUnknownParameterSet, kind, length)
+	case 1:
+		return matches[0], nil
+	default:
+		levels := make([]string, len(matches))
+		for i, mayo := range matches {
+			levels[i] = fmt.Sprint(mayo.securityLevel)
+		}
+		return nil, fmt.Errorf("%w: %s of %d bytes belongs to levels %s", ErrAmbiguousParameterSet, kind, length,
+			strings.Join(levels, " and "))
+	}
+}
@@ -0,0 +1,82 @@
+package mayo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectParameterSet(t *testing.T) {
+	for _, securityLevel := range securityLevels {
+		mayo, _ := InitMayo(securityLevel)
+
+		detected, err := DetectParameterSet(make([]byte, mayo.cpkBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detected.SecurityLevel() != securityLevel {
+			t.Error("Detected level", detected.SecurityLevel(), "from public key, expected", securityLevel)
+		}
+
+		detected, err = DetectParameterSetFromSignature(make([]byte, mayo.sigBytes))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if detected.SecurityLevel() != securityLevel {
+			t.Error("Detected level", detected.SecurityLevel(), "from signature, expected", securityLevel)
+		}
+	}
+
+	if _, err := DetectParameterSet(make([]byte, 100)); !errors.Is(err, ErrUnknownParameterSet) {
+		t.Error("Expected ErrUnknownParameterSet, got:", err)
+	}
+
+	// Levels 1 and 2 both have 24 byte secret keys
+	if _, err := DetectParameterSetFromPrivateKey(make([]byte, 24)); !errors.Is(err, ErrAmbiguousParameterSet) {
+		t.Error("Expected ErrAmbiguousParameterSet, got:", err)
+	}
+	detected, err := DetectParameterSetFromPrivateKey(make([]byte, 40))
+	if err != nil || detected.SecurityLevel() != 5 {
+		t.Error("Expected level 5 from a 40 byte secret key, got:", detected, err)
+	}
+}
+
+func TestVerifyAuto(t *testing.T) {
+	message := []byte("message of an unknown parameter set")
+	signatures := make(map[int][]byte)
+	publicKeys := make(map[int][]byte)
+
+	for _, securityLevel := range securityLevels {
+		mayo, _ := InitMayo(securityLevel)
+		cpk, csk, err := mayo.CompactKeyGen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := mayo.SignDetached(csk, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys[securityLevel], signatures[securityLevel] = cpk, sig
+
+		detected, err := VerifyAuto(cpk, message, sig)
+		if err != nil {
+			t.Fatal("Expected valid signature for security level", securityLevel, "got:", err)
+		}
+		if detected.SecurityLevel() != securityLevel {
+			t.Error("Detected level", detected.SecurityLevel(), "expected", securityLevel)
+		}
+
+		if _, err = VerifyAuto(cpk, []byte("other message"), sig); !errors.Is(err, ErrInvalidSignature) {
+			t.Error("Expected ErrInvalidSignature, got:", err)
+		}
+	}
+
+	if _, err := VerifyAuto(publicKeys[1], message, signatures[5]); !errors.Is(err, ErrParameterSetMismatch) {
+		t.Error("Expected ErrParameterSetMismatch, got:", err)
+	}
+	if _, err := VerifyAuto(publicKeys[1], message, signatures[1][1:]); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength, got:", err)
+	}
+	if _, err := VerifyAuto(publicKeys[1][1:], message, signatures[1]); !errors.Is(err, ErrUnknownParameterSet) {
+		t.Error("Expected ErrUnknownParameterSet, got:", err)
+	}
+}
@@ -0,0 +1,56 @@
+package mayo
+
+import "errors"
+
+var (
+	// ErrInvalidPublicKey is returned when a compact public key does not have the size of the parameter set
+	ErrInvalidPublicKey = errors.New("mayo: invalid public key length")
+
+	// ErrKeyPairMismatch is returned when a compact secret key does not belong to a compact public key
+	ErrKeyPairMismatch = errors.New("mayo: secret key does not match the public key")
+
+	// ErrInvalidPrivateKey is returned when a compact secret key does not have the size of the parameter set
+	ErrInvalidPrivateKey = errors.New("mayo: invalid private key length")
+
+	// ErrInvalidSeedLength is returned when a seed does not have the size of seedSk of the parameter set
+	ErrInvalidSeedLength = errors.New("mayo: invalid seed length")
+
+	// ErrInvalidExpandedPublicKey is returned when an expanded public key does not have the size of the parameter set
+	ErrInvalidExpandedPublicKey = errors.New("mayo: invalid expanded public key length")
+
+	// ErrInvalidExpandedPrivateKey is returned when an expanded secret key does not have the size of the parameter set
+	ErrInvalidExpandedPrivateKey = errors.New("mayo: invalid expanded private key length")
+
+	// ErrInvalidSignatureLength is returned when a signature does not have the size of the parameter set
+	ErrInvalidSignatureLength = errors.New("mayo: invalid signature length")
+
+	// ErrInvalidSignature is returned when a signature is not valid on the message
+	ErrInvalidSignature = errors.New("mayo: invalid signature")
+
+	// ErrInvalidDigestLength is returned when a message digest does not have the length of the parameter set or hash
+	ErrInvalidDigestLength = errors.New("mayo: invalid digest length")
+
+	// ErrUnsupportedHash is returned when a pre-hash function is not supported by HashMAYO
+	ErrUnsupportedHash = errors.New("mayo: unsupported hash function")
+
+	// ErrContextTooLong is returned when a context string is longer than MaxContextLength
+	ErrContextTooLong = errors.New("mayo: context string is longer than 255 bytes")
+
+	// ErrInvalidSignedMessage is returned when a signed message sig || m is shorter than a signature
+	ErrInvalidSignedMessage = errors.New("mayo: signed message is shorter than a signature")
+
+	// ErrSigningFailed is returned when Sign does not find a preimage within MaxSigningAttempts attempts
+	ErrSigningFailed = errors.New("mayo: signing failed, no solution found within the maximum amount of attempts")
+
+	// ErrParameterSetMismatch is returned when objects from different parameter sets are combined
+	ErrParameterSetMismatch = errors.New("mayo: parameter set mismatch")
+
+	// ErrUnknownParameterSet is returned when the length of a key or signature is not the one of any parameter set
+	ErrUnknownParameterSet = errors.New("mayo: length does not match any parameter set")
+
+	// ErrAmbiguousParameterSet is returned when the length of a key or signature is the one of several parameter sets
+	ErrAmbiguousParameterSet = errors.New("mayo: length matches several parameter sets")
+
+	// ErrMissingParameterSet is returned when unmarshalling into a key or signature that is not bound to a parameter set
+	ErrMissingParameterSet = errors.New("mayo: no parameter set, construct the value using a Mayo instance")
+)
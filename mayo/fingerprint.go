@@ -0,0 +1,138 @@
+package mayo
+
+import (
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"mayo-go/rand"
+	"strings"
+)
+
+// The fingerprint of a public key is
+//
+//	SHAKE256("MAYO-FINGERPRINT" || security level (1) || cpk, FingerprintSize)
+//
+// which binds the parameter set, and is computed over the compact public key cpk, such that it never requires
+// expanding the key. As seedPk cannot be recovered from an expanded public key, expanded public keys have no
+// fingerprint. The key ID is the first KeyIDSize bytes of the fingerprint.
+
+const (
+	// FingerprintSize is the size of a fingerprint in bytes
+	FingerprintSize = 32
+
+	// KeyIDSize is the size of a key ID in bytes
+	KeyIDSize = 8
+)
+
+const fingerprintDomain = "MAYO-FINGERPRINT"
+
+// Fingerprint identifies a public key and its parameter set
+type Fingerprint [FingerprintSize]byte
+
+// KeyID is a short identifier of a public key, which is the prefix of its fingerprint
+type KeyID [KeyIDSize]byte
+
+// Fingerprint computes the fingerprint of the compact public key cpk. Will instead return ErrInvalidPublicKey, if cpk
+// does not have the length of the parameter set.
+func (mayo *Mayo) Fingerprint(cpk []byte) (Fingerprint, error) {
+	if len(cpk) != mayo.cpkBytes {
+		return Fingerprint{}, ErrInvalidPublicKey
+	}
+	return mayo.fingerprint(cpk), nil
+}
+
+// fingerprint computes the fingerprint of the compact public key cpk as described above
+func (mayo *Mayo) fingerprint(cpk []byte) Fingerprint {
+	digest := rand.Shake256(FingerprintSize, []byte(fingerprintDomain), []byte{byte(mayo.securityLevel)}, cpk)
+	return Fingerprint(digest)
+}
+
+// Fingerprint returns the fingerprint of the public key
+func (pk *PublicKey) Fingerprint() Fingerprint {
+	return pk.mayo.fingerprint(pk.cpk)
+}
+
+// KeyID returns the key ID of the public key
+func (pk *PublicKey) KeyID() KeyID {
+	return pk.Fingerprint().KeyID()
+}
+
+// KeyID returns the key ID, which is the prefix of the fingerprint
+func (f Fingerprint) KeyID() KeyID {
+	return KeyID(f[:KeyIDSize])
+}
+
+// String returns the fingerprint in hex
+func (f Fingerprint) String() string {
+	return f.Hex()
+}
+
+// Hex returns the fingerprint in lowercase hex
+func (f Fingerprint) Hex() string {
+	return hex.EncodeToString(f[:])
+}
+
+// Base32 returns the fingerprint in base32 of RFC 4648 without padding
+func (f Fingerprint) Base32() string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(f[:])
+}
+
+// Randomart returns the fingerprint as an image of 17 by 9 characters in a frame, using the drunken bishop algorithm of
+// OpenSSH. The bishop starts in the center, and every two bits of the fingerprint, from the least significant bits of
+// the first byte, move it diagonally. The character of a cell reflects how often it was visited.
+func (f Fingerprint) Randomart(securityLevel int) string {
+	const (
+		width   = 17
+		height  = 9
+		symbols = " .o+=*BOX@%&#/^"
+	)
+
+	var field [width][height]int
+	x, y := width/2, height/2
+	for _, b := range f {
+		for i := 0; i < 4; i++ {
+			if b&0x1 != 0 {
+				x = min(x+1, width-1)
+			} else {
+				x = max(x-1, 0)
+			}
+			if b&0x2 != 0 {
+				y = min(y+1, height-1)
+			} else {
+				y = max(y-1, 0)
+			}
+			field[x][y] = min(field[x][y]+1, len(symbols)-1)
+			b >>= 2
+		}
+	}
+
+	var art strings.Builder
+	art.WriteString(frame(fmt.Sprintf("[MAYO-%d]", securityLevel), width))
+	for row := 0; row < height; row++ {
+		art.WriteByte('|')
+		for column := 0; column < width; column++ {
+			switch {
+			case column == width/2 && row == height/2:
+				art.WriteByte('S')
+			case column == x && row == y:
+				art.WriteByte('E')
+			default:
+				art.WriteByte(symbols[field[column][row]])
+			}
+		}
+		art.WriteString("|\n")
+	}
+	art.WriteString(frame("[SHAKE256]", width))
+	return art.String()
+}
+
+// String returns the key ID in lowercase hex
+func (id KeyID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// frame returns a horizontal border of the randomart with title in the center
+func frame(title string, width int) string {
+	left := (width - len(title)) / 2
+	return "+" + strings.Repeat("-", left) + title + strings.Repeat("-", width-left-len(title)) + "+\n"
+}
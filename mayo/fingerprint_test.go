@@ -0,0 +1,92 @@
+package mayo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintOfPublicKeys(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		mayo, err := InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pk, _, err := mayo.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fingerprint, err := mayo.Fingerprint(pk.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fingerprint != pk.Fingerprint() {
+			t.Error("Fingerprints of compact key and public key differ for security level", securityLevel)
+		}
+		if pk.KeyID() != fingerprint.KeyID() || pk.KeyID().String() != fingerprint.Hex()[:2*KeyIDSize] {
+			t.Error("Key ID is not the prefix of the fingerprint for security level", securityLevel)
+		}
+
+		if _, err = mayo.Fingerprint(pk.Bytes()[1:]); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Error("Expected ErrInvalidPublicKey, got:", err)
+		}
+		if _, err = mayo.Fingerprint(pk.Expand().Bytes()); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Error("Expected ErrInvalidPublicKey for an expanded public key, got:", err)
+		}
+	}
+}
+
+func TestFingerprintIsStable(t *testing.T) {
+	mayo, _ := InitMayo(2)
+	pk, _, err := mayo.GenerateKeyFromSeed(make([]byte, mayo.SeedSize()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fingerprints are logged and compared across versions, thus must never change
+	if pk.Fingerprint().Hex() != "5a7c3885295ee07e648d874adff23245a395480ae54919165454fc676c091dcf" {
+		t.Error("Fingerprint changed", pk.Fingerprint().Hex())
+	}
+}
+
+func TestFingerprintBindsParameterSet(t *testing.T) {
+	// The fingerprint is over the same bytes, thus only the parameter set differs
+	cpk := make([]byte, 1)
+	mayo1, _ := InitMayo(1)
+	mayo2, _ := InitMayo(2)
+	if mayo1.fingerprint(cpk) == mayo2.fingerprint(cpk) {
+		t.Error("Fingerprint does not depend on the parameter set")
+	}
+}
+
+func TestFingerprintFormats(t *testing.T) {
+	var fingerprint Fingerprint
+	for i := range fingerprint {
+		fingerprint[i] = byte(i)
+	}
+
+	if fingerprint.Hex() != "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f" {
+		t.Error("Hex is wrong", fingerprint.Hex())
+	}
+	if fingerprint.Base32() != "AAAQEAYEAUDAOCAJBIFQYDIOB4IBCEQTCQKRMFYYDENBWHA5DYPQ" {
+		t.Error("Base32 is wrong", fingerprint.Base32())
+	}
+	if fingerprint.KeyID().String() != "0001020304050607" {
+		t.Error("Key ID is wrong", fingerprint.KeyID())
+	}
+
+	lines := strings.Split(strings.TrimSuffix(fingerprint.Randomart(2), "\n"), "\n")
+	if len(lines) != 11 || lines[0] != "+----[MAYO-2]-----+" || lines[10] != "+---[SHAKE256]----+" {
+		t.Fatal("Frame of randomart is wrong\n" + strings.Join(lines, "\n"))
+	}
+	for _, line := range lines[1:10] {
+		if len(line) != 19 || line[0] != '|' || line[18] != '|' {
+			t.Error("Row of randomart is wrong", line)
+		}
+	}
+	if lines[5][9] != 'S' {
+		t.Error("Randomart does not start in the center")
+	}
+}
@@ -0,0 +1,278 @@
+package mayo
+
+import "bytes"
+
+// PublicKey is a compact public key cpk, bound to the parameter set it belongs to
+type PublicKey struct {
+	mayo *Mayo
+	cpk  []byte
+}
+
+// PrivateKey is a compact secret key csk, bound to the parameter set it belongs to
+type PrivateKey struct {
+	mayo   *Mayo
+	csk    []byte
+	public *PublicKey
+}
+
+// ExpandedPublicKey is an expanded public key epk, bound to the parameter set it belongs to
+type ExpandedPublicKey struct {
+	mayo *Mayo
+	epk  []byte
+}
+
+// ExpandedPrivateKey is an expanded secret key esk, bound to the parameter set it belongs to
+type ExpandedPrivateKey struct {
+	mayo *Mayo
+	esk  []byte
+}
+
+// Signature is a signature sig, bound to the parameter set it belongs to
+type Signature struct {
+	mayo *Mayo
+	sig  []byte
+}
+
+// GenerateKey calls CompactKeyGen and returns the keys as typed objects
+func (mayo *Mayo) GenerateKey() (*PublicKey, *PrivateKey, error) {
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pk := &PublicKey{mayo: mayo, cpk: cpk}
+	return pk, &PrivateKey{mayo: mayo, csk: csk, public: pk}, nil
+}
+
+// GenerateKeyFromSeed calls KeyGenFromSeed and returns the keys as typed objects
+func (mayo *Mayo) GenerateKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey, error) {
+	cpk, csk, err := mayo.KeyGenFromSeed(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pk := &PublicKey{mayo: mayo, cpk: cpk}
+	return pk, &PrivateKey{mayo: mayo, csk: csk, public: pk}, nil
+}
+
+// NewPublicKey returns a PublicKey holding a copy of cpk, if cpk has the correct length
+func (mayo *Mayo) NewPublicKey(cpk []byte) (*PublicKey, error) {
+	pk := &PublicKey{mayo: mayo}
+	if err := pk.UnmarshalBinary(cpk); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// NewPrivateKey returns a PrivateKey holding a copy of csk, if csk has the correct length
+func (mayo *Mayo) NewPrivateKey(csk []byte) (*PrivateKey, error) {
+	sk := &PrivateKey{mayo: mayo}
+	if err := sk.UnmarshalBinary(csk); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// NewExpandedPublicKey returns an ExpandedPublicKey holding a copy of epk, if epk has the correct length
+func (mayo *Mayo) NewExpandedPublicKey(epk []byte) (*ExpandedPublicKey, error) {
+	pk := &ExpandedPublicKey{mayo: mayo}
+	if err := pk.UnmarshalBinary(epk); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+// NewExpandedPrivateKey returns an ExpandedPrivateKey holding a copy of esk, if esk has the correct length
+func (mayo *Mayo) NewExpandedPrivateKey(esk []byte) (*ExpandedPrivateKey, error) {
+	sk := &ExpandedPrivateKey{mayo: mayo}
+	if err := sk.UnmarshalBinary(esk); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+// NewSignature returns a Signature holding a copy of sig, if sig has the correct length
+func (mayo *Mayo) NewSignature(sig []byte) (*Signature, error) {
+	s := &Signature{mayo: mayo}
+	if err := s.UnmarshalBinary(sig); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Mayo returns the parameter set of the public key
+func (pk *PublicKey) Mayo() *Mayo {
+	return pk.mayo
+}
+
+// Bytes returns a copy of the compact public key cpk
+func (pk *PublicKey) Bytes() []byte {
+	return bytes.Clone(pk.cpk)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return pk.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The public key must already be bound to a parameter set
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	cpk, err := checkLength(pk.mayo, data, func(mayo *Mayo) int { return mayo.cpkBytes }, ErrInvalidPublicKey)
+	if err != nil {
+		return err
+	}
+	pk.cpk = cpk
+	return nil
+}
+
+// Expand calls ExpandPK on the public key
+func (pk *PublicKey) Expand() *ExpandedPublicKey {
+	// The length of cpk is validated on construction, thus ExpandPK cannot fail
+	epk, _ := pk.mayo.ExpandPK(pk.cpk)
+	return &ExpandedPublicKey{mayo: pk.mayo, epk: epk}
+}
+
+// Mayo returns the parameter set of the private key
+func (sk *PrivateKey) Mayo() *Mayo {
+	return sk.mayo
+}
+
+// Bytes returns a copy of the compact secret key csk
+func (sk *PrivateKey) Bytes() []byte {
+	return bytes.Clone(sk.csk)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	return sk.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The private key must already be bound to a parameter set.
+// The public key is derived from the secret key, such that it is available through Public
+func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
+	csk, err := checkLength(sk.mayo, data, func(mayo *Mayo) int { return mayo.cskBytes }, ErrInvalidPrivateKey)
+	if err != nil {
+		return err
+	}
+	cpk, _ := sk.mayo.compactKeyGen(csk)
+	sk.csk = csk
+	sk.public = &PublicKey{mayo: sk.mayo, cpk: cpk}
+	return nil
+}
+
+// Expand calls ExpandSK on the private key
+func (sk *PrivateKey) Expand() *ExpandedPrivateKey {
+	// The length of csk is validated on construction, thus ExpandSK cannot fail
+	esk, _ := sk.mayo.ExpandSK(sk.csk)
+	return &ExpandedPrivateKey{mayo: sk.mayo, esk: esk}
+}
+
+// Mayo returns the parameter set of the expanded public key
+func (epk *ExpandedPublicKey) Mayo() *Mayo {
+	return epk.mayo
+}
+
+// Bytes returns a copy of the expanded public key epk
+func (epk *ExpandedPublicKey) Bytes() []byte {
+	return bytes.Clone(epk.epk)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (epk *ExpandedPublicKey) MarshalBinary() ([]byte, error) {
+	return epk.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The key must already be bound to a parameter set
+func (epk *ExpandedPublicKey) UnmarshalBinary(data []byte) error {
+	b, err := checkLength(epk.mayo, data, func(mayo *Mayo) int { return mayo.epkBytes }, ErrInvalidExpandedPublicKey)
+	if err != nil {
+		return err
+	}
+	epk.epk = b
+	return nil
+}
+
+// Verify calls Verify with the expanded public key, and reports whether sig is a valid signature on m. Signatures
+// from another parameter set are never valid
+func (epk *ExpandedPublicKey) Verify(m []byte, sig *Signature) bool {
+	if !sameParameterSet(epk.mayo, sig.mayo) {
+		return false
+	}
+	result, err := epk.mayo.Verify(epk.epk, m, sig.sig)
+	return err == nil && result == 0
+}
+
+// Mayo returns the parameter set of the expanded private key
+func (esk *ExpandedPrivateKey) Mayo() *Mayo {
+	return esk.mayo
+}
+
+// Bytes returns a copy of the expanded secret key esk
+func (esk *ExpandedPrivateKey) Bytes() []byte {
+	return bytes.Clone(esk.esk)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (esk *ExpandedPrivateKey) MarshalBinary() ([]byte, error) {
+	return esk.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The key must already be bound to a parameter set
+func (esk *ExpandedPrivateKey) UnmarshalBinary(data []byte) error {
+	b, err := checkLength(esk.mayo, data, func(mayo *Mayo) int { return mayo.eskBytes }, ErrInvalidExpandedPrivateKey)
+	if err != nil {
+		return err
+	}
+	esk.esk = b
+	return nil
+}
+
+// Sign calls Sign with the expanded secret key, and returns the signature on m
+func (esk *ExpandedPrivateKey) Sign(m []byte) (*Signature, error) {
+	sig, err := esk.mayo.Sign(esk.esk, m)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{mayo: esk.mayo, sig: sig}, nil
+}
+
+// Mayo returns the parameter set of the signature
+func (s *Signature) Mayo() *Mayo {
+	return s.mayo
+}
+
+// Bytes returns a copy of the signature
+func (s *Signature) Bytes() []byte {
+	return bytes.Clone(s.sig)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (s *Signature) MarshalBinary() ([]byte, error) {
+	return s.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The signature must already be bound to a parameter set
+func (s *Signature) UnmarshalBinary(data []byte) error {
+	sig, err := checkLength(s.mayo, data, func(mayo *Mayo) int { return mayo.sigBytes }, ErrInvalidSignatureLength)
+	if err != nil {
+		return err
+	}
+	s.sig = sig
+	return nil
+}
+
+// checkLength returns a copy of data if its length matches the length given by the parameter set
+func checkLength(mayo *Mayo, data []byte, length func(*Mayo) int, lengthErr error) ([]byte, error) {
+	if mayo == nil {
+		return nil, ErrMissingParameterSet
+	}
+	if len(data) != length(mayo) {
+		return nil, lengthErr
+	}
+	return bytes.Clone(data), nil
+}
+
+// sameParameterSet checks if two instances of MAYO use the same parameter set
+func sameParameterSet(a, b *Mayo) bool {
+	return a != nil && b != nil && a.securityLevel == b.securityLevel
+}
@@ -0,0 +1,144 @@
+package mayo
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*PublicKey)(nil)
+	_ encoding.BinaryUnmarshaler = (*PublicKey)(nil)
+	_ encoding.BinaryMarshaler   = (*PrivateKey)(nil)
+	_ encoding.BinaryUnmarshaler = (*PrivateKey)(nil)
+	_ encoding.BinaryMarshaler   = (*ExpandedPublicKey)(nil)
+	_ encoding.BinaryUnmarshaler = (*ExpandedPublicKey)(nil)
+	_ encoding.BinaryMarshaler   = (*ExpandedPrivateKey)(nil)
+	_ encoding.BinaryUnmarshaler = (*ExpandedPrivateKey)(nil)
+	_ encoding.BinaryMarshaler   = (*Signature)(nil)
+	_ encoding.BinaryUnmarshaler = (*Signature)(nil)
+)
+
+func TestTypedKeysSignAndVerify(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte("This is a message.")
+	sig, err := sk.Expand().Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	epk := pk.Expand()
+
+	if !epk.Verify(message, sig) {
+		t.Error("Signature should be valid")
+	}
+
+	if epk.Verify([]byte("This is another message."), sig) {
+		t.Error("Signature should not be valid on another message")
+	}
+}
+
+func TestTypedKeysMarshalRoundTrip(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, _ := pk.MarshalBinary()
+	parsedPk, err := mayo.NewPublicKey(cpk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsedPk.Bytes(), pk.Bytes()) {
+		t.Error("Public key did not survive round trip")
+	}
+
+	csk, _ := sk.MarshalBinary()
+	parsedSk, err := mayo.NewPrivateKey(csk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsedSk.Bytes(), sk.Bytes()) {
+		t.Error("Private key did not survive round trip")
+	}
+
+	esk, _ := sk.Expand().MarshalBinary()
+	if _, err = mayo.NewExpandedPrivateKey(esk); err != nil {
+		t.Error(err)
+	}
+
+	epk, _ := pk.Expand().MarshalBinary()
+	if _, err = mayo.NewExpandedPublicKey(epk); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTypedKeysRejectWrongLength(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = mayo.NewPublicKey(make([]byte, mayo.cpkBytes-1)); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Error("Expected ErrInvalidPublicKey, got:", err)
+	}
+	if _, err = mayo.NewPrivateKey(make([]byte, mayo.eskBytes)); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Error("Expected ErrInvalidPrivateKey, got:", err)
+	}
+	if _, err = mayo.NewExpandedPublicKey(make([]byte, mayo.cpkBytes)); !errors.Is(err, ErrInvalidExpandedPublicKey) {
+		t.Error("Expected ErrInvalidExpandedPublicKey, got:", err)
+	}
+	if _, err = mayo.NewExpandedPrivateKey(make([]byte, mayo.cskBytes)); !errors.Is(err, ErrInvalidExpandedPrivateKey) {
+		t.Error("Expected ErrInvalidExpandedPrivateKey, got:", err)
+	}
+	if _, err = mayo.NewSignature(make([]byte, mayo.sigBytes+1)); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength, got:", err)
+	}
+
+	var pk PublicKey
+	if err = pk.UnmarshalBinary(make([]byte, mayo.cpkBytes)); !errors.Is(err, ErrMissingParameterSet) {
+		t.Error("Expected ErrMissingParameterSet, got:", err)
+	}
+}
+
+func TestTypedKeysRejectOtherParameterSet(t *testing.T) {
+	mayo1, _ := InitMayo(1)
+	mayo2, _ := InitMayo(2)
+
+	pk, sk, err := mayo2.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Level 1 and 2 share the secret key length, but public keys differ in length
+	if _, err = mayo1.NewPrivateKey(sk.Bytes()); err != nil {
+		t.Error(err)
+	}
+	if _, err = mayo1.NewPublicKey(pk.Bytes()); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Error("Expected ErrInvalidPublicKey, got:", err)
+	}
+
+	message := []byte("This is a message.")
+	sig, err := sk.Expand().Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSig := &Signature{mayo: mayo1, sig: sig.sig}
+	if pk.Expand().Verify(message, otherSig) {
+		t.Error("Signature from another parameter set should not be valid")
+	}
+}
@@ -2,6 +2,7 @@ package mayo
 
 import (
 	"bytes"
+	"errors"
 	"math"
 	"mayo-go/field"
 	"mayo-go/rand"
@@ -12,8 +13,41 @@ import (
 // return an error, if it fails to generate random bytes.
 func (mayo *Mayo) CompactKeyGen() ([]byte, []byte, error) {
 	// Pick seekSk at random
-	seedSk := rand.SampleRandomBytes(mayo.skSeedBytes)
+	seedSk, err := rand.SampleRandomBytes(mayo.random, mayo.skSeedBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Derive the keys from seedSk
+	cpk, csk := mayo.compactKeyGen(seedSk)
+	return cpk, csk, nil
+}
+
+// KeyGenFromSeed is CompactKeyGen with the seed seedSk given by the caller, such that the same seed always results in
+// the same keys. The compact secret key csk is a copy of the seed. Will instead return ErrInvalidSeedLength, if seed
+// does not have length SeedSize.
+func (mayo *Mayo) KeyGenFromSeed(seed []byte) ([]byte, []byte, error) {
+	if len(seed) != mayo.skSeedBytes {
+		return nil, nil, ErrInvalidSeedLength
+	}
+
+	cpk, csk := mayo.compactKeyGen(bytes.Clone(seed))
+	return cpk, csk, nil
+}
+
+// PublicKeyFromSecret recomputes the compact public key cpk from the compact secret key csk, which is the seed seedSk.
+// Will instead return ErrInvalidPrivateKey, if csk does not have the length of the parameter set.
+func (mayo *Mayo) PublicKeyFromSecret(csk []byte) ([]byte, error) {
+	if len(csk) != mayo.cskBytes {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	cpk, _ := mayo.compactKeyGen(csk)
+	return cpk, nil
+}
 
+// compactKeyGen is the deterministic part of CompactKeyGen, which derives the keys from seedSk
+func (mayo *Mayo) compactKeyGen(seedSk []byte) ([]byte, []byte) {
 	// Derive seedPk and O from seekSk
 	s := rand.Shake256(mayo.pkSeedBytes+mayo.oBytes, seedSk)
 	seedPk := s[:mayo.pkSeedBytes]
@@ -37,11 +71,16 @@ func (mayo *Mayo) CompactKeyGen() ([]byte, []byte, error) {
 	csk := seedSk
 
 	// Output keys
-	return cpk, csk, nil
+	return cpk, csk
 }
 
-// ExpandSK (Algorithm 5) takes the compacted secret key csk and outputs an expanded secret key esk
-func (mayo *Mayo) ExpandSK(csk []byte) []byte {
+// ExpandSK (Algorithm 5) takes the compacted secret key csk and outputs an expanded secret key esk. Will instead
+// return ErrInvalidPrivateKey, if csk does not have the length of the parameter set.
+func (mayo *Mayo) ExpandSK(csk []byte) ([]byte, error) {
+	if len(csk) != mayo.cskBytes {
+		return nil, ErrInvalidPrivateKey
+	}
+
 	// Parse csk
 	seedSk := csk[:mayo.skSeedBytes]
 
@@ -69,11 +108,16 @@ func (mayo *Mayo) ExpandSK(csk []byte) []byte {
 	copy(esk[mayo.skSeedBytes:], oByteString)
 	copy(esk[mayo.skSeedBytes+mayo.oBytes:], p1Bytes)
 	copy(esk[mayo.skSeedBytes+mayo.oBytes+mayo.p1Bytes:], encodeMatrices(mayo.v, mayo.o, L, false))
-	return esk
+	return esk, nil
 }
 
-// ExpandPK (Algorithm 6) takes the compacted public key csk and outputs an expanded public key epk
-func (mayo *Mayo) ExpandPK(cpk []byte) []byte {
+// ExpandPK (Algorithm 6) takes the compacted public key cpk and outputs an expanded public key epk. Will instead
+// return ErrInvalidPublicKey, if cpk does not have the length of the parameter set.
+func (mayo *Mayo) ExpandPK(cpk []byte) ([]byte, error) {
+	if len(cpk) != mayo.cpkBytes {
+		return nil, ErrInvalidPublicKey
+	}
+
 	// Parse cpk
 	seedPk := cpk[:mayo.pkSeedBytes]
 
@@ -81,28 +125,57 @@ func (mayo *Mayo) ExpandPK(cpk []byte) []byte {
 	epk := make([]byte, mayo.epkBytes)
 	copy(epk[:mayo.p1Bytes+mayo.p2Bytes], rand.Aes128ctr(seedPk, mayo.p1Bytes+mayo.p2Bytes))
 	copy(epk[mayo.p1Bytes+mayo.p2Bytes:], cpk[mayo.pkSeedBytes:mayo.pkSeedBytes+mayo.p3Bytes])
-	return epk
+	return epk, nil
+}
+
+// Sign (Algorithm 7) takes an expanded secret key esk and a message m and outputs a signature on the message m. Will
+// instead return ErrInvalidExpandedPrivateKey, if esk does not have the length of the parameter set, or
+// ErrSigningFailed, if no preimage was found within MaxSigningAttempts attempts.
+func (mayo *Mayo) Sign(esk, m []byte) ([]byte, error) {
+	sig, _, err := mayo.SignWithAttempts(esk, m)
+	return sig, err
+}
+
+// SignWithAttempts is Sign, but also outputs the number of attempts used to find a preimage, which is between 1 and
+// MaxSigningAttempts. If signing fails with ErrSigningFailed, the number of attempts is MaxSigningAttempts.
+func (mayo *Mayo) SignWithAttempts(esk, m []byte) ([]byte, int, error) {
+	if len(esk) != mayo.eskBytes {
+		return nil, 0, ErrInvalidExpandedPrivateKey
+	}
+
+	R, err := rand.SampleRandomBytes(mayo.random, mayo.rBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return mayo.sign(esk, m, R)
+}
+
+// sign is SignWithAttempts with the randomness R supplied by the caller
+func (mayo *Mayo) sign(esk, m, R []byte) ([]byte, int, error) {
+	return mayo.signDigest(esk, rand.Shake256(mayo.digestBytes, m), R)
 }
 
-// Sign (Algorithm 7) takes an expanded secret key esk and a message m and outputs a signature on the message m
-func (mayo *Mayo) Sign(esk, m []byte) []byte {
+// signDigest is sign on the digest of the message, which is SHAKE256 of the message with length digestBytes
+func (mayo *Mayo) signDigest(esk, mDigest, R []byte) ([]byte, int, error) {
 	// Decode esk
 	seedSk := esk[:mayo.skSeedBytes]
 	O := decodeMatrix(mayo.v, mayo.o, esk[mayo.skSeedBytes:mayo.skSeedBytes+mayo.oBytes])
 	P1 := decodeMatrices(mayo.m, mayo.v, mayo.v, esk[mayo.skSeedBytes+mayo.oBytes:mayo.skSeedBytes+mayo.oBytes+mayo.p1Bytes], true)
 	L := decodeMatrices(mayo.m, mayo.v, mayo.o, esk[mayo.skSeedBytes+mayo.oBytes+mayo.p1Bytes:mayo.eskBytes], false)
 
-	// Hash the message, and derive salt and t
-	mDigest := rand.Shake256(mayo.digestBytes, m)
-	R := rand.SampleRandomBytes(mayo.rBytes)
+	// Derive salt and t
 	salt := rand.Shake256(mayo.saltBytes, mDigest, R, seedSk)
 	t := decodeVec(mayo.m, rand.Shake256(mayo.intTimesLogQ(mayo.m), mDigest, salt))
 
 	// Attempt to find a preimage for t
 	var x []byte
 	var hasSolution bool
+	var attempts int
 	v := make([][]byte, mayo.k)
-	for ctr := 0; ctr < 256; ctr++ {
+	for ctr := 0; ctr < MaxSigningAttempts && !hasSolution; ctr++ {
+		attempts = ctr + 1
+
 		// Derive v_i and r
 		V := rand.Shake256(mayo.k*mayo.vBytes+mayo.intTimesLogQ(mayo.k, mayo.o), mDigest, salt, seedSk, []byte{byte(ctr)})
 		for i := 0; i < mayo.k; i++ {
@@ -174,10 +247,10 @@ func (mayo *Mayo) Sign(esk, m []byte) []byte {
 		A = mayo.reduceAModF(A)
 
 		// Try to solve the system
-		x, hasSolution = mayo.sampleSolution(A, y, r)
-		if hasSolution {
-			break
-		}
+		x, hasSolution = mayo.solver(A, y, r)
+	}
+	if !hasSolution {
+		return nil, attempts, ErrSigningFailed
 	}
 
 	// Finish and output the signature
@@ -191,12 +264,27 @@ func (mayo *Mayo) Sign(esk, m []byte) []byte {
 	var sig []byte
 	sig = append(sig, encodeVec(s)...)
 	sig = append(sig, salt...)
-	return sig
+	return sig, attempts, nil
 }
 
 // Verify (Algorithm 8) takes an expanded public key, message m, and signature sig and outputs an integer to indicate
-// if the signature is valid on m. Specifically if the signature is valid it will output 0, if invalid < 0.
-func (mayo *Mayo) Verify(epk, m, sig []byte) int {
+// if the signature is valid on m. Specifically if the signature is valid it will output 0, if invalid < 0. If epk or
+// sig does not have the length of the parameter set, it outputs < 0 along with ErrInvalidExpandedPublicKey or
+// ErrInvalidSignatureLength.
+func (mayo *Mayo) Verify(epk, m, sig []byte) (int, error) {
+	if len(epk) != mayo.epkBytes {
+		return -1, ErrInvalidExpandedPublicKey
+	}
+	if len(sig) != mayo.sigBytes {
+		return -1, ErrInvalidSignatureLength
+	}
+
+	// Hash the message and check the signature on the digest
+	return mayo.verifyDigest(epk, rand.Shake256(mayo.digestBytes, m), sig), nil
+}
+
+// verifyDigest is Verify on the digest of the message, where the lengths of epk and sig have already been checked
+func (mayo *Mayo) verifyDigest(epk, mDigest, sig []byte) int {
 	// Decode epk
 	P1ByteString := epk[:mayo.p1Bytes]
 	P2ByteString := epk[mayo.p1Bytes : mayo.p1Bytes+mayo.p2Bytes]
@@ -215,8 +303,7 @@ func (mayo *Mayo) Verify(epk, m, sig []byte) int {
 		copy(sVector[i], s[i*mayo.n:(i+1)*mayo.n])
 	}
 
-	// Hash the message and derive t
-	mDigest := rand.Shake256(mayo.digestBytes, m)
+	// Derive t
 	t := decodeVec(mayo.m, rand.Shake256(mayo.intTimesLogQ(mayo.m), mDigest, salt))
 
 	// Compute P^*(s)
@@ -264,39 +351,72 @@ func (mayo *Mayo) Verify(epk, m, sig []byte) int {
 	return -1
 }
 
-// APISign (Algorithm 9) Takes a secret sk and message, it then expands the SK and calls Sign with the expanded secret key
-// to produce the signature. It then outputs sig || M
-func (mayo *Mayo) APISign(M, sk []byte) []byte {
-	// Expand the SK
-	esk := mayo.ExpandSK(sk)
+// SignDetached takes a compact secret key csk and a message m and outputs a signature on m, which is not prepended to
+// the message. The secret key is expanded internally, and m is only read. Will instead return ErrInvalidPrivateKey, if
+// csk does not have the length of the parameter set, or ErrSigningFailed, if no preimage was found.
+func (mayo *Mayo) SignDetached(csk, m []byte) ([]byte, error) {
+	esk, err := mayo.ExpandSK(csk)
+	if err != nil {
+		return nil, err
+	}
+	return mayo.Sign(esk, m)
+}
+
+// VerifyDetached takes a compact public key cpk, a message m and a signature sig, and returns nil if the signature is
+// valid on m. The public key is expanded internally, and m is only read. Will instead return ErrInvalidSignature, if
+// the signature is not valid, or ErrInvalidPublicKey or ErrInvalidSignatureLength, if cpk or sig does not have the
+// length of the parameter set.
+func (mayo *Mayo) VerifyDetached(cpk, m, sig []byte) error {
+	epk, err := mayo.ExpandPK(cpk)
+	if err != nil {
+		return err
+	}
+
+	result, err := mayo.Verify(epk, m, sig)
+	if err != nil {
+		return err
+	} else if result != 0 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
 
+// APISign (Algorithm 9) Takes a secret sk and message, and signs the message using SignDetached. It then outputs
+// sig || M
+func (mayo *Mayo) APISign(M, sk []byte) ([]byte, error) {
 	// Produce signature
-	sig := mayo.Sign(esk, M)
+	sig, err := mayo.SignDetached(sk, M)
+	if err != nil {
+		return nil, err
+	}
 
 	// Return signed message
 	result := make([]byte, mayo.sigBytes+len(M))
 	copy(result[:mayo.sigBytes], sig)
 	copy(result[mayo.sigBytes:], M)
-	return result
+	return result, nil
 }
 
-// APISignOpen (Algorithm 10) Takes a signed message sig || m as input and expands the public key, which then calls
-// Verify to check if the signature is valid. It returns the result and message if the signature is valid
-func (mayo *Mayo) APISignOpen(sm, pk []byte) (int, []byte) {
-	// Expand the PK
-	epk := mayo.ExpandPK(pk)
-
+// APISignOpen (Algorithm 10) Takes a signed message sig || m as input and checks the signature using VerifyDetached,
+// without copying the message. It returns the result and message if the signature is valid. Will return
+// ErrInvalidSignedMessage if sm is shorter than a signature
+func (mayo *Mayo) APISignOpen(sm, pk []byte) (int, []byte, error) {
 	// Parse the signed message
+	if len(sm) < mayo.sigBytes {
+		return -1, nil, ErrInvalidSignedMessage
+	}
 	sig, M := sm[:mayo.sigBytes], sm[mayo.sigBytes:]
 
 	// Verify the signature
-	result := mayo.Verify(epk, M, sig)
+	err := mayo.VerifyDetached(pk, M, sig)
+	if errors.Is(err, ErrInvalidSignature) {
+		return -1, nil, nil
+	} else if err != nil {
+		return -1, nil, err
+	}
 
 	// Return result and message
-	if result < 0 {
-		return result, nil
-	}
-	return result, M
+	return 0, M, nil
 }
 
 func (mayo *Mayo) intTimesLogQ(ints ...int) int {
@@ -2,7 +2,9 @@
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"mayo-go/rand"
 	"testing"
 )
 
@@ -24,8 +26,16 @@ func BenchmarkMayo_APISign(b *testing.B) {
 	}
 
 	// Sign and open the signature
-	sig := mayo.APISign(message, csk)
-	result, signedMessage := mayo.APISignOpen(sig, cpk)
+	sig, err := mayo.APISign(message, csk)
+	if err != nil {
+		b.Error(err)
+		return
+	}
+	result, signedMessage, err := mayo.APISignOpen(sig, cpk)
+	if err != nil {
+		b.Error(err)
+		return
+	}
 
 	if result != 0 {
 		b.Error("Result should be '0', was: ", result)
@@ -35,3 +45,266 @@ func BenchmarkMayo_APISign(b *testing.B) {
 		b.Error("Signed message is not equal to opened message", message, signedMessage)
 	}
 }
+
+func TestMalformedInputReturnsErrors(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	epk, err := mayo.ExpandPK(cpk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+
+	if _, err = mayo.ExpandSK(csk[1:]); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Error("Expected ErrInvalidPrivateKey, got:", err)
+	}
+
+	if _, err = mayo.ExpandPK(cpk[:10]); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Error("Expected ErrInvalidPublicKey, got:", err)
+	}
+
+	if _, err = mayo.Sign(csk, message); !errors.Is(err, ErrInvalidExpandedPrivateKey) {
+		t.Error("Expected ErrInvalidExpandedPrivateKey, got:", err)
+	}
+
+	if _, err = mayo.Verify(epk, message, make([]byte, 10)); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength, got:", err)
+	}
+
+	if _, err = mayo.Verify(cpk, message, make([]byte, mayo.sigBytes)); !errors.Is(err, ErrInvalidExpandedPublicKey) {
+		t.Error("Expected ErrInvalidExpandedPublicKey, got:", err)
+	}
+
+	if _, _, err = mayo.APISignOpen(make([]byte, mayo.sigBytes-1), cpk); !errors.Is(err, ErrInvalidSignedMessage) {
+		t.Error("Expected ErrInvalidSignedMessage, got:", err)
+	}
+
+	if _, err = mayo.APISign(message, cpk); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Error("Expected ErrInvalidPrivateKey, got:", err)
+	}
+}
+
+func TestSignReportsAttempts(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, err := mayo.ExpandSK(csk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Let the first two attempts fail
+	calls := 0
+	mayo.solver = func(A [][]byte, y, r []byte) ([]byte, bool) {
+		calls++
+		if calls <= 2 {
+			return nil, false
+		}
+		return mayo.sampleSolution(A, y, r)
+	}
+
+	_, attempts, err := mayo.SignWithAttempts(esk, []byte("This is a message."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != calls {
+		t.Error("Expected attempts to equal the amount of calls to the solver", attempts, calls)
+	}
+	if attempts < 3 {
+		t.Error("Expected at least 3 attempts, got:", attempts)
+	}
+}
+
+func TestSignFailsWhenNoSolutionIsFound(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, err := mayo.ExpandSK(csk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mayo.solver = func(A [][]byte, y, r []byte) ([]byte, bool) {
+		return nil, false
+	}
+
+	sig, attempts, err := mayo.SignWithAttempts(esk, []byte("This is a message."))
+	if !errors.Is(err, ErrSigningFailed) {
+		t.Error("Expected ErrSigningFailed, got:", err)
+	}
+	if sig != nil {
+		t.Error("Expected no signature, got:", sig)
+	}
+	if attempts != MaxSigningAttempts {
+		t.Error("Expected all attempts to be used, got:", attempts)
+	}
+
+	if _, err = mayo.APISign([]byte("This is a message."), csk); !errors.Is(err, ErrSigningFailed) {
+		t.Error("Expected APISign to return ErrSigningFailed, got:", err)
+	}
+}
+
+func TestWithRandomUsesGivenReader(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed := make([]byte, 48)
+	drbgA, _ := rand.NewCTRDRBG(seed, nil)
+	drbgB, _ := rand.NewCTRDRBG(seed, nil)
+
+	cpkA, cskA, err := mayo.WithRandom(drbgA).CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpkB, cskB, err := mayo.WithRandom(drbgB).CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(cpkA, cpkB) || !bytes.Equal(cskA, cskB) {
+		t.Error("Keys generated from the same seed should be equal")
+	}
+
+	if mayo.random != nil {
+		t.Error("WithRandom should not modify the original instance")
+	}
+}
+
+func TestSignDetachedAndVerifyDetached(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+
+	sig, err := mayo.SignDetached(csk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sig) != mayo.sigBytes {
+		t.Error("Signature should not include the message, length was:", len(sig))
+	}
+	if err = mayo.VerifyDetached(cpk, message, sig); err != nil {
+		t.Error("Expected valid signature, got:", err)
+	}
+
+	if err = mayo.VerifyDetached(cpk, []byte("This is another message."), sig); !errors.Is(err, ErrInvalidSignature) {
+		t.Error("Expected ErrInvalidSignature, got:", err)
+	}
+	if err = mayo.VerifyDetached(cpk, message, sig[1:]); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength, got:", err)
+	}
+	if err = mayo.VerifyDetached(cpk[1:], message, sig); !errors.Is(err, ErrInvalidPublicKey) {
+		t.Error("Expected ErrInvalidPublicKey, got:", err)
+	}
+	if _, err = mayo.SignDetached(cpk, message); !errors.Is(err, ErrInvalidPrivateKey) {
+		t.Error("Expected ErrInvalidPrivateKey, got:", err)
+	}
+}
+
+func TestSignDetachedMatchesAPISign(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+
+	seed := make([]byte, 48)
+	drbgA, _ := rand.NewCTRDRBG(seed, nil)
+	drbgB, _ := rand.NewCTRDRBG(seed, nil)
+
+	sig, err := mayo.WithRandom(drbgA).SignDetached(csk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm, err := mayo.WithRandom(drbgB).APISign(message, csk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sm, append(sig, message...)) {
+		t.Error("APISign should output the detached signature followed by the message")
+	}
+}
+
+func TestKeyGenFromSeed(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		mayo, err := InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seed := make([]byte, mayo.SeedSize())
+		for i := range seed {
+			seed[i] = byte(i)
+		}
+
+		cpk, csk, err := mayo.KeyGenFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(csk, seed) {
+			t.Error("Compact secret key should be the seed", securityLevel)
+		}
+		csk[0] ^= 1
+		if seed[0] != 0 {
+			t.Error("Compact secret key should not share memory with the seed", securityLevel)
+		}
+		csk[0] ^= 1
+
+		// CompactKeyGen with a reader outputting the seed results in the same keys
+		expectedCpk, _, err := mayo.WithRandom(bytes.NewReader(seed)).CompactKeyGen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(cpk, expectedCpk) {
+			t.Error("KeyGenFromSeed should match CompactKeyGen on the same seed", securityLevel)
+		}
+
+		recomputed, err := mayo.PublicKeyFromSecret(csk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(recomputed, cpk) {
+			t.Error("PublicKeyFromSecret should recompute the public key", securityLevel)
+		}
+
+		if _, _, err = mayo.KeyGenFromSeed(seed[1:]); !errors.Is(err, ErrInvalidSeedLength) {
+			t.Error("Expected ErrInvalidSeedLength, got:", err)
+		}
+		if _, err = mayo.PublicKeyFromSecret(append(csk, 0)); !errors.Is(err, ErrInvalidPrivateKey) {
+			t.Error("Expected ErrInvalidPrivateKey, got:", err)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package mayo
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"mayo-go/field"
 )
 
+// MaxSigningAttempts is the amount of attempts Sign makes to find a preimage, as ctr is a single byte
+const MaxSigningAttempts = 256
+
 type Mayo struct {
 	// MAYO is parameterized by the following (missing F, which is the polynomial)
 	q, m, n, o, k, saltBytes, digestBytes, pkSeedBytes int
@@ -19,19 +23,34 @@ type Mayo struct {
 	v, shifts int
 
 	field *field.Field
+
+	// solver samples a solution to the linear system in Sign, and is only replaced in tests
+	solver func(A [][]byte, y, r []byte) ([]byte, bool)
+
+	// securityLevel is the level the parameters were initialized with, see InitMayo
+	securityLevel int
+
+	// random is the source of randomness for key generation and signing, if nil crypto/rand.Reader is used
+	random io.Reader
 }
 
 // InitMayo initializes mayo with the correct parameters according to the specification. Note that
 // mayo has 4 levels: 1, 2, 3, and 5.
 func InitMayo(securityLevel int) (*Mayo, error) {
+	var mayo *Mayo
 	if securityLevel == 1 {
-		return initMayo(86, 78, 8, 10, 16, 24, 32, 16, []byte{8, 1, 1, 0}), nil
+		mayo = initMayo(86, 78, 8, 10, 16, 24, 32, 16, []byte{8, 1, 1, 0})
 	} else if securityLevel == 2 {
-		return initMayo(81, 64, 17, 4, 16, 24, 32, 16, []byte{8, 0, 2, 8}), nil
+		mayo = initMayo(81, 64, 17, 4, 16, 24, 32, 16, []byte{8, 0, 2, 8})
 	} else if securityLevel == 3 {
-		return initMayo(118, 108, 10, 11, 16, 32, 48, 16, []byte{8, 0, 1, 7}), nil
+		mayo = initMayo(118, 108, 10, 11, 16, 32, 48, 16, []byte{8, 0, 1, 7})
 	} else if securityLevel == 5 {
-		return initMayo(154, 142, 12, 12, 16, 40, 64, 16, []byte{4, 0, 8, 1}), nil
+		mayo = initMayo(154, 142, 12, 12, 16, 40, 64, 16, []byte{4, 0, 8, 1})
+	}
+
+	if mayo != nil {
+		mayo.securityLevel = securityLevel
+		return mayo, nil
 	}
 
 	return nil, errors.New(
@@ -60,7 +79,7 @@ func initMayo(n, m, o, k, q, saltBytes, digestBytes, pkSeedBytes int, tailF []by
 	v := n - o
 	shifts := k * (k + 1) / 2
 
-	return &Mayo{
+	mayo := &Mayo{
 		q:           q,
 		m:           m,
 		n:           n,
@@ -88,4 +107,41 @@ func initMayo(n, m, o, k, q, saltBytes, digestBytes, pkSeedBytes int, tailF []by
 		shifts:      shifts,
 		field:       field.InitField(),
 	}
+	mayo.solver = mayo.sampleSolution
+
+	return mayo
+}
+
+// WithRandom returns a copy of mayo, which reads the randomness for key generation and signing from reader instead of
+// the entropy of the operating system. The copy can be used concurrently with the original, as they share no state.
+// Passing a deterministic reader such as rand.CTRDRBG is only meant for reproducing known answer tests.
+func (mayo *Mayo) WithRandom(reader io.Reader) *Mayo {
+	copied := *mayo
+	copied.random = reader
+	return &copied
+}
+
+// SecurityLevel returns the security level (1, 2, 3, or 5) of the parameter set
+func (mayo *Mayo) SecurityLevel() int {
+	return mayo.securityLevel
+}
+
+// SeedSize returns skSeedBytes, which is the length of the seed of KeyGenFromSeed and of the compact secret key csk
+func (mayo *Mayo) SeedSize() int {
+	return mayo.skSeedBytes
+}
+
+// PublicKeySize returns cpkBytes, which is the length of the compact public key cpk
+func (mayo *Mayo) PublicKeySize() int {
+	return mayo.cpkBytes
+}
+
+// PrivateKeySize returns cskBytes, which is the length of the compact secret key csk
+func (mayo *Mayo) PrivateKeySize() int {
+	return mayo.cskBytes
+}
+
+// SignatureSize returns sigBytes, which is the length of a signature
+func (mayo *Mayo) SignatureSize() int {
+	return mayo.sigBytes
 }
@@ -0,0 +1,111 @@
+package mayo
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"mayo-go/rand"
+)
+
+// hashMayoCustomization is the cSHAKE256 customization string of the message digest of HashMAYO, which separates it
+// from the SHAKE256 digest of Sign and from SignWithContext
+const hashMayoCustomization = "MAYO HashMAYO"
+
+// hashOIDs maps the hash functions supported by HashMAYO to their object identifiers
+var hashOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+	crypto.SHA256:   {2, 16, 840, 1, 101, 3, 4, 2, 1},
+	crypto.SHA384:   {2, 16, 840, 1, 101, 3, 4, 2, 2},
+	crypto.SHA512:   {2, 16, 840, 1, 101, 3, 4, 2, 3},
+	crypto.SHA3_256: {2, 16, 840, 1, 101, 3, 4, 2, 8},
+	crypto.SHA3_384: {2, 16, 840, 1, 101, 3, 4, 2, 9},
+	crypto.SHA3_512: {2, 16, 840, 1, 101, 3, 4, 2, 10},
+}
+
+// DigestSize returns digestBytes, which is the length of the SHAKE256 digest of the message that is signed
+func (mayo *Mayo) DigestSize() int {
+	return mayo.digestBytes
+}
+
+// Digest outputs the digest of the message m, which is SHAKE256 of m with length DigestSize. It is the digest that is
+// computed by Sign and Verify, thus SignDigest on Digest(m) results in a signature on m.
+func (mayo *Mayo) Digest(m []byte) []byte {
+	return rand.Shake256(mayo.digestBytes, m)
+}
+
+// SignDigest is Sign on the message whose digest is mDigest, see Digest. Will instead return ErrInvalidDigestLength, if
+// mDigest does not have length DigestSize, or any error of Sign.
+func (mayo *Mayo) SignDigest(esk, mDigest []byte) ([]byte, error) {
+	if len(esk) != mayo.eskBytes {
+		return nil, ErrInvalidExpandedPrivateKey
+	}
+	if len(mDigest) != mayo.digestBytes {
+		return nil, ErrInvalidDigestLength
+	}
+
+	R, err := rand.SampleRandomBytes(mayo.random, mayo.rBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, _, err := mayo.signDigest(esk, mDigest, R)
+	return sig, err
+}
+
+// VerifyDigest is Verify on the message whose digest is mDigest, see Digest. Will instead output < 0 and
+// ErrInvalidDigestLength, if mDigest does not have length DigestSize, or any error of Verify.
+func (mayo *Mayo) VerifyDigest(epk, mDigest, sig []byte) (int, error) {
+	if len(epk) != mayo.epkBytes {
+		return -1, ErrInvalidExpandedPublicKey
+	}
+	if len(sig) != mayo.sigBytes {
+		return -1, ErrInvalidSignatureLength
+	}
+	if len(mDigest) != mayo.digestBytes {
+		return -1, ErrInvalidDigestLength
+	}
+
+	return mayo.verifyDigest(epk, mDigest, sig), nil
+}
+
+// SignHashed (HashMAYO) signs the digest of a message with the context string ctx, see SignWithContext, where digest
+// was computed with the hash function hash, such as crypto.SHA256, crypto.SHA512 or crypto.SHA3_256. The message
+// digest is cSHAKE256 of len(ctx) || ctx || OID(hash) || digest with the customization string "MAYO HashMAYO", where
+// OID is the DER encoding of the object identifier of hash, thus the hash function is bound to the signature. As Sign
+// computes the message digest with SHAKE256, no message signed by Sign or SignWithContext results in the digest of a
+// HashMAYO signature, or the other way around. Will instead return ErrUnsupportedHash, if hash is not supported,
+// ErrInvalidDigestLength, if the length of digest does not match hash, ErrContextTooLong, if ctx is longer than
+// MaxContextLength, or any error of SignDigest.
+func (mayo *Mayo) SignHashed(esk []byte, hash crypto.Hash, digest, ctx []byte) ([]byte, error) {
+	if hash == crypto.Hash(0) {
+		return nil, ErrUnsupportedHash
+	}
+	mDigest, err := mayo.encodedDigest(digest, hash, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mayo.SignDigest(esk, mDigest)
+}
+
+// VerifyHashed (HashMAYO) verifies a signature of SignHashed on digest with the context string ctx, where digest was
+// computed with the hash function hash. Will instead output < 0 and any error of SignHashed or VerifyDigest.
+func (mayo *Mayo) VerifyHashed(epk []byte, hash crypto.Hash, digest, sig, ctx []byte) (int, error) {
+	if hash == crypto.Hash(0) {
+		return -1, ErrUnsupportedHash
+	}
+	mDigest, err := mayo.encodedDigest(digest, hash, ctx)
+	if err != nil {
+		return -1, err
+	}
+	return mayo.VerifyDigest(epk, mDigest, sig)
+}
+
+// hashOID outputs the DER encoding of the object identifier of hash, after checking that digest has the size of hash
+func hashOID(hash crypto.Hash, digest []byte) ([]byte, error) {
+	oid, ok := hashOIDs[hash]
+	if !ok {
+		return nil, ErrUnsupportedHash
+	}
+	if len(digest) != hash.Size() {
+		return nil, ErrInvalidDigestLength
+	}
+	return asn1.Marshal(oid)
+}
@@ -0,0 +1,125 @@
+package mayo
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSignDigestMatchesSign(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, _ := mayo.ExpandSK(csk)
+	epk, _ := mayo.ExpandPK(cpk)
+	message := []byte("This is a message.")
+
+	// A signature on the digest is a signature on the message, and the other way around
+	sig, err := mayo.SignDigest(esk, mayo.Digest(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := mayo.Verify(epk, message, sig); result != 0 || err != nil {
+		t.Error("Signature of SignDigest should be valid on the message, got:", result, err)
+	}
+
+	sig, err = mayo.Sign(esk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, err := mayo.VerifyDigest(epk, mayo.Digest(message), sig); result != 0 || err != nil {
+		t.Error("Signature of Sign should be valid on the digest, got:", result, err)
+	}
+
+	if _, err = mayo.SignDigest(esk, message); !errors.Is(err, ErrInvalidDigestLength) {
+		t.Error("Expected ErrInvalidDigestLength, got:", err)
+	}
+	if _, err = mayo.VerifyDigest(epk, message, sig); !errors.Is(err, ErrInvalidDigestLength) {
+		t.Error("Expected ErrInvalidDigestLength, got:", err)
+	}
+}
+
+func TestHashMayo(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, _ := mayo.ExpandSK(csk)
+	epk, _ := mayo.ExpandPK(cpk)
+	message := []byte("This is a message.")
+
+	sha256Digest := sha256.Sum256(message)
+	sha512Digest := sha512.Sum512(message)
+	sha3Digest := sha3.Sum256(message)
+	digests := map[crypto.Hash][]byte{
+		crypto.SHA256:   sha256Digest[:],
+		crypto.SHA512:   sha512Digest[:],
+		crypto.SHA3_256: sha3Digest[:],
+	}
+
+	for hash, digest := range digests {
+		sig, err := mayo.SignHashed(esk, hash, digest, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result, err := mayo.VerifyHashed(epk, hash, digest, sig, nil); result != 0 || err != nil {
+			t.Error("HashMAYO signature should be valid, got:", hash, result, err)
+		}
+		if result, _ := mayo.Verify(epk, digest, sig); result == 0 {
+			t.Error("HashMAYO signature should not be valid as a plain signature on the digest", hash)
+		}
+		if result, _ := mayo.Verify(epk, message, sig); result == 0 {
+			t.Error("HashMAYO signature should not be valid as a plain signature on the message", hash)
+		}
+	}
+
+	// The hash function is bound to the signature, SHA-256 and SHA3-256 have digests of the same length
+	sig, err := mayo.SignHashed(esk, crypto.SHA256, sha256Digest[:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := mayo.VerifyHashed(epk, crypto.SHA3_256, sha256Digest[:], sig, nil); result == 0 {
+		t.Error("HashMAYO signature should not be valid for another hash function")
+	}
+
+	// A plain signature on the encoding of the HashMAYO message is not a HashMAYO signature
+	encodedOID, err := hashOID(crypto.SHA256, sha256Digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, encoded := range [][]byte{
+		slices.Concat([]byte{0}, encodedOID, sha256Digest[:]),
+		slices.Concat([]byte{0x01, 0}, encodedOID, sha256Digest[:]),
+	} {
+		plainSig, err := mayo.Sign(esk, encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result, _ := mayo.VerifyHashed(epk, crypto.SHA256, sha256Digest[:], plainSig, nil); result == 0 {
+			t.Error("Plain signature on an encoded message should not be valid as a HashMAYO signature")
+		}
+	}
+
+	if _, err = mayo.SignHashed(esk, crypto.MD5, make([]byte, 16), nil); !errors.Is(err, ErrUnsupportedHash) {
+		t.Error("Expected ErrUnsupportedHash, got:", err)
+	}
+	if _, err = mayo.SignHashed(esk, crypto.SHA512, sha256Digest[:], nil); !errors.Is(err, ErrInvalidDigestLength) {
+		t.Error("Expected ErrInvalidDigestLength, got:", err)
+	}
+}
@@ -0,0 +1,172 @@
+package mayo
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"io"
+	"mayo-go/rand"
+)
+
+var (
+	_ crypto.Signer = (*PrivateKey)(nil)
+)
+
+// Public returns the public key corresponding to the private key, implementing crypto.Signer. If the public key is not
+// known, it is derived from csk, and if the private key holds no key, such as the zero value, nil is returned.
+func (sk *PrivateKey) Public() crypto.PublicKey {
+	if sk.public != nil {
+		return sk.public
+	}
+	if sk.mayo == nil || len(sk.csk) != sk.mayo.cskBytes {
+		return nil
+	}
+
+	cpk, _ := sk.mayo.compactKeyGen(sk.csk)
+	return &PublicKey{mayo: sk.mayo, cpk: cpk}
+}
+
+// Randomness selects how the randomness R of a signature is derived
+type Randomness int
+
+const (
+	// RandomizedSigning reads R from the source of randomness, which is the default
+	RandomizedSigning Randomness = iota
+
+	// DeterministicSigning sets R to all zeros, as permitted by the specification, such that the signature only
+	// depends on the key and message. The salt is still derived from seedSk, thus remains secret.
+	DeterministicSigning
+
+	// HedgedSigning derives R as SHAKE256 of randomness read from the source of randomness and the entropy given by
+	// the caller, such that R is unpredictable if either of them is
+	HedgedSigning
+)
+
+// SignerOpts are the options of PrivateKey.Sign and SignWithOptions, implementing crypto.SignerOpts
+type SignerOpts struct {
+	// Hash is the hash function msg was computed with for HashMAYO, or zero if msg is the message itself
+	Hash crypto.Hash
+
+	// Context is the context string of at most MaxContextLength bytes, see SignWithContext
+	Context []byte
+
+	// Randomness selects how R is derived, and Entropy is the entropy of the caller used by HedgedSigning
+	Randomness Randomness
+	Entropy    []byte
+}
+
+// HashFunc returns opts.Hash, implementing crypto.SignerOpts
+func (opts *SignerOpts) HashFunc() crypto.Hash {
+	return opts.Hash
+}
+
+// Sign signs msg with the private key, implementing crypto.Signer. The randomness R is read from random, or from the
+// source of the parameter set if random is nil. If opts.HashFunc() is crypto.Hash(0), msg is the message, and
+// otherwise msg is the digest of the message, which is signed with HashMAYO as in SignHashed. A context string and
+// the derivation of R are selected if opts is a *SignerOpts. Note that the private key is expanded on every call, use
+// Expand for repeated signing
+func (sk *PrivateKey) Sign(random io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	esk, err := sk.mayo.ExpandSK(sk.csk)
+	if err != nil {
+		return nil, err
+	}
+	if random == nil {
+		random = sk.mayo.random
+	}
+	return sk.mayo.signWithOptions(esk, msg, random, signerOptions(opts))
+}
+
+// SignWithOptions is Sign with the options of PrivateKey.Sign, reading R from the source of the parameter set. Will
+// instead return any error of SignHashed or SignWithContext, or an error if opts.Randomness is not known.
+func (mayo *Mayo) SignWithOptions(esk, msg []byte, opts *SignerOpts) ([]byte, error) {
+	if len(esk) != mayo.eskBytes {
+		return nil, ErrInvalidExpandedPrivateKey
+	}
+	return mayo.signWithOptions(esk, msg, mayo.random, signerOptions(opts))
+}
+
+// signWithOptions signs msg with opts, where the length of esk has already been checked
+func (mayo *Mayo) signWithOptions(esk, msg []byte, random io.Reader, opts SignerOpts) ([]byte, error) {
+	mDigest, err := mayo.encodedDigest(msg, opts.Hash, opts.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	var R []byte
+	switch opts.Randomness {
+	case RandomizedSigning:
+		R, err = rand.SampleRandomBytes(random, mayo.rBytes)
+	case DeterministicSigning:
+		R = make([]byte, mayo.rBytes)
+	case HedgedSigning:
+		var fresh []byte
+		fresh, err = rand.SampleRandomBytes(random, mayo.rBytes)
+		R = rand.Shake256(mayo.rBytes, fresh, opts.Entropy)
+	default:
+		return nil, errors.New("mayo: unknown randomness mode")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sig, _, err := mayo.signDigest(esk, mDigest, R)
+	return sig, err
+}
+
+// Equal reports whether pk and x are the same public key of the same parameter set, implementing the interface that
+// all crypto.PublicKey types in the standard library satisfy
+func (pk *PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return sameParameterSet(pk.mayo, other.mayo) && bytes.Equal(pk.cpk, other.cpk)
+}
+
+// Verify reports whether sig is a valid signature on msg by pub. It returns false if sig does not have the length of
+// the parameter set of pub
+func Verify(pub *PublicKey, msg, sig []byte) bool {
+	return VerifyWithOptions(pub, msg, sig, nil)
+}
+
+// VerifyHashed reports whether sig is a valid HashMAYO signature by pub on digest, which was computed with hash, such
+// as the signature of PrivateKey.Sign with opts.HashFunc() set to hash
+func VerifyHashed(pub *PublicKey, hash crypto.Hash, digest, sig []byte) bool {
+	if hash == crypto.Hash(0) {
+		return false
+	}
+	return VerifyWithOptions(pub, digest, sig, hash)
+}
+
+// VerifyWithOptions reports whether sig is a valid signature by pub on msg, which was signed by PrivateKey.Sign with
+// opts, such as a *SignerOpts with a context string
+func VerifyWithOptions(pub *PublicKey, msg, sig []byte, opts crypto.SignerOpts) bool {
+	options := signerOptions(opts)
+	mDigest, err := pub.mayo.encodedDigest(msg, options.Hash, options.Context)
+	if err != nil {
+		return false
+	}
+
+	epk, err := pub.mayo.ExpandPK(pub.cpk)
+	if err != nil {
+		return false
+	}
+	result, err := pub.mayo.VerifyDigest(epk, mDigest, sig)
+	return err == nil && result == 0
+}
+
+// signerOptions returns the options of opts, which may be nil, where only the hash function is known if opts is not a
+// *SignerOpts
+func signerOptions(opts crypto.SignerOpts) SignerOpts {
+	switch o := opts.(type) {
+	case nil:
+		return SignerOpts{}
+	case *SignerOpts:
+		if o == nil {
+			return SignerOpts{}
+		}
+		return *o
+	default:
+		return SignerOpts{Hash: opts.HashFunc()}
+	}
+}
@@ -0,0 +1,180 @@
+package mayo
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	mayorand "mayo-go/rand"
+	"testing"
+)
+
+// deterministicSignatureHash is the SHA-256 hash of the deterministic signature in TestDeterministicSigning
+const deterministicSignatureHash = "60ff20d4b8d87750a9eafcb1710a88a9bde8322f8e9d679d7286ad659b5e6202"
+
+func TestSignerSignAndVerify(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var signer crypto.Signer = sk
+	message := []byte("This is a message.")
+	sig, err := signer.Sign(rand.Reader, message, crypto.Hash(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !Verify(pk, message, sig) {
+		t.Error("Signature should be valid")
+	}
+
+	if Verify(pk, []byte("This is another message."), sig) {
+		t.Error("Signature should not be valid on another message")
+	}
+
+	if Verify(pk, message, sig[1:]) {
+		t.Error("Truncated signature should not be valid")
+	}
+
+	digest := sha256.Sum256(message)
+	hashedSig, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyHashed(pk, crypto.SHA256, digest[:], hashedSig) {
+		t.Error("HashMAYO signature should be valid")
+	}
+	if Verify(pk, digest[:], hashedSig) {
+		t.Error("HashMAYO signature should not be valid as a signature on the digest")
+	}
+}
+
+func TestSignerPublicKeyEqual(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pk.Equal(sk.Public()) {
+		t.Error("Public key of the private key should equal the generated public key")
+	}
+
+	parsedSk, err := mayo.NewPrivateKey(sk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pk.Equal(parsedSk.Public()) {
+		t.Error("Public key derived from the secret key should equal the generated public key")
+	}
+
+	// A private key without its public key derives it, and the zero value has none
+	if !pk.Equal((&PrivateKey{mayo: mayo, csk: sk.Bytes()}).Public()) {
+		t.Error("Public key derived from csk should equal the generated public key")
+	}
+	if public := new(PrivateKey).Public(); public != nil {
+		t.Error("Expected a nil public key for the zero value, got:", public)
+	}
+
+	otherPk, _, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Equal(otherPk) {
+		t.Error("Different public keys should not be equal")
+	}
+}
+
+func TestDeterministicSigning(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Generate the key from a fixed seed, such that the signature can be compared to a fixed value
+	drbg, _ := mayorand.NewCTRDRBG(make([]byte, 48), nil)
+	pk, sk, err := mayo.WithRandom(drbg).GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+	opts := &SignerOpts{Randomness: DeterministicSigning}
+
+	sigA, err := sk.Sign(rand.Reader, message, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigB, err := sk.Sign(rand.Reader, message, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sigA, sigB) {
+		t.Error("Deterministic signatures on the same message should be equal")
+	}
+	if !Verify(pk, message, sigA) {
+		t.Error("Deterministic signature should be valid")
+	}
+
+	digest := sha256.Sum256(sigA)
+	if hex.EncodeToString(digest[:]) != deterministicSignatureHash {
+		t.Error("Deterministic signature changed, SHA-256 of the signature is:", hex.EncodeToString(digest[:]))
+	}
+
+	esk, _ := mayo.ExpandSK(sk.Bytes())
+	sigC, err := mayo.SignWithOptions(esk, message, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sigA, sigC) {
+		t.Error("SignWithOptions should output the same deterministic signature")
+	}
+}
+
+func TestHedgedSigning(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := mayo.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("This is a message.")
+
+	sign := func(entropy []byte) []byte {
+		drbg, _ := mayorand.NewCTRDRBG(make([]byte, 48), nil)
+		sig, err := sk.Sign(drbg, message, &SignerOpts{Randomness: HedgedSigning, Entropy: entropy})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Verify(pk, message, sig) {
+			t.Error("Hedged signature should be valid")
+		}
+		return sig
+	}
+
+	// With fixed randomness, the signature only depends on the entropy of the caller
+	if !bytes.Equal(sign([]byte("entropy A")), sign([]byte("entropy A"))) {
+		t.Error("Hedged signatures with the same randomness and entropy should be equal")
+	}
+	if bytes.Equal(sign([]byte("entropy A")), sign([]byte("entropy B"))) {
+		t.Error("Hedged signatures with different entropy should not be equal")
+	}
+
+	if _, err = sk.Sign(nil, message, &SignerOpts{Randomness: Randomness(3)}); err == nil {
+		t.Error("Expected error for unknown randomness mode")
+	}
+}
@@ -0,0 +1,107 @@
+package mayo
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"io"
+	"mayo-go/rand"
+)
+
+var (
+	_ io.Writer = (*Signer)(nil)
+	_ io.Writer = (*Verifier)(nil)
+)
+
+// Signer signs a message which is written to it incrementally. As MAYO only uses the message through its SHAKE256
+// digest, the message is absorbed as it is written and never held in memory. The signature is identical to the
+// signature of Sign on the whole message, given the same randomness.
+type Signer struct {
+	mayo *Mayo
+	esk  []byte
+	hash *sha3.SHAKE
+}
+
+// Verifier verifies a signature on a message which is written to it incrementally, such that the result is identical
+// to the result of Verify on the whole message.
+type Verifier struct {
+	mayo *Mayo
+	epk  []byte
+	hash *sha3.SHAKE
+}
+
+// NewSigner returns a Signer holding a copy of the expanded secret key esk. Will instead return
+// ErrInvalidExpandedPrivateKey, if esk does not have the length of the parameter set.
+func (mayo *Mayo) NewSigner(esk []byte) (*Signer, error) {
+	if len(esk) != mayo.eskBytes {
+		return nil, ErrInvalidExpandedPrivateKey
+	}
+	return &Signer{mayo: mayo, esk: bytes.Clone(esk), hash: sha3.NewSHAKE256()}, nil
+}
+
+// NewVerifier returns a Verifier holding a copy of the expanded public key epk. Will instead return
+// ErrInvalidExpandedPublicKey, if epk does not have the length of the parameter set.
+func (mayo *Mayo) NewVerifier(epk []byte) (*Verifier, error) {
+	if len(epk) != mayo.epkBytes {
+		return nil, ErrInvalidExpandedPublicKey
+	}
+	return &Verifier{mayo: mayo, epk: bytes.Clone(epk), hash: sha3.NewSHAKE256()}, nil
+}
+
+// Write absorbs p into the digest of the message, it never returns an error
+func (s *Signer) Write(p []byte) (int, error) {
+	return s.hash.Write(p)
+}
+
+// Sign outputs a signature on the message written so far, reading the randomness R from the source of the parameter
+// set. More of the message may be written afterwards, and Sign called again. Will return ErrSigningFailed, if no
+// preimage was found within MaxSigningAttempts attempts.
+func (s *Signer) Sign() ([]byte, error) {
+	mDigest, err := digest(s.hash, s.mayo.digestBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	R, err := rand.SampleRandomBytes(s.mayo.random, s.mayo.rBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, _, err := s.mayo.signDigest(s.esk, mDigest, R)
+	return sig, err
+}
+
+// Write absorbs p into the digest of the message, it never returns an error
+func (v *Verifier) Write(p []byte) (int, error) {
+	return v.hash.Write(p)
+}
+
+// Verify checks sig on the message written so far. Like Verify it outputs 0 if the signature is valid, and < 0 if it
+// is invalid. If sig does not have the length of the parameter set, it outputs < 0 and ErrInvalidSignatureLength.
+func (v *Verifier) Verify(sig []byte) (int, error) {
+	if len(sig) != v.mayo.sigBytes {
+		return -1, ErrInvalidSignatureLength
+	}
+
+	mDigest, err := digest(v.hash, v.mayo.digestBytes)
+	if err != nil {
+		return -1, err
+	}
+	return v.mayo.verifyDigest(v.epk, mDigest, sig), nil
+}
+
+// digest squeezes length bytes from a copy of hash, such that more input can still be absorbed by hash
+func digest(hash *sha3.SHAKE, length int) ([]byte, error) {
+	state, err := hash.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	clone := sha3.NewSHAKE256()
+	if err = clone.UnmarshalBinary(state); err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, length)
+	_, _ = clone.Read(output)
+	return output, nil
+}
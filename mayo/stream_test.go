@@ -0,0 +1,111 @@
+package mayo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mayo-go/rand"
+	"testing"
+)
+
+func TestSignerMatchesSign(t *testing.T) {
+	mayo, err := InitMayo(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	esk, err := mayo.ExpandSK(csk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := bytes.Repeat([]byte("This is a message."), 1000)
+
+	seed := make([]byte, 48)
+	drbgA, _ := rand.NewCTRDRBG(seed, nil)
+	drbgB, _ := rand.NewCTRDRBG(seed, nil)
+
+	expected, err := mayo.WithRandom(drbgA).Sign(esk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := mayo.WithRandom(drbgB).NewSigner(esk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write the message in chunks that do not align with the rate of SHAKE256
+	if _, err = io.CopyBuffer(signer, bytes.NewReader(message), make([]byte, 7)); err != nil {
+		t.Fatal(err)
+	}
+	// The signer holds a copy of esk, thus changing the buffer of the caller does not change the key
+	clear(esk)
+	sig, err := signer.Sign()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sig, expected) {
+		t.Error("Streamed signature should be identical to the signature of Sign")
+	}
+}
+
+func TestVerifierMatchesVerify(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cpk, csk, err := mayo.CompactKeyGen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	epk, err := mayo.ExpandPK(cpk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := bytes.Repeat([]byte("This is a message."), 1000)
+
+	sig, err := mayo.SignDetached(csk, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := mayo.NewVerifier(epk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = verifier.Write(message[:100])
+	if result, _ := verifier.Verify(sig); result == 0 {
+		t.Error("Signature should not be valid on a prefix of the message")
+	}
+
+	// Verify does not finalize the digest, thus the rest of the message can still be written. The verifier holds a
+	// copy of epk, thus changing the buffer of the caller does not change the key.
+	_, _ = verifier.Write(message[100:])
+	clear(epk)
+	if result, err := verifier.Verify(sig); result != 0 || err != nil {
+		t.Error("Expected valid signature, got:", result, err)
+	}
+
+	if _, err = verifier.Verify(sig[1:]); !errors.Is(err, ErrInvalidSignatureLength) {
+		t.Error("Expected ErrInvalidSignatureLength, got:", err)
+	}
+}
+
+func TestNewSignerAndVerifierRejectWrongLength(t *testing.T) {
+	mayo, err := InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = mayo.NewSigner(make([]byte, 10)); !errors.Is(err, ErrInvalidExpandedPrivateKey) {
+		t.Error("Expected ErrInvalidExpandedPrivateKey, got:", err)
+	}
+	if _, err = mayo.NewVerifier(make([]byte, 10)); !errors.Is(err, ErrInvalidExpandedPublicKey) {
+		t.Error("Expected ErrInvalidExpandedPublicKey, got:", err)
+	}
+}
@@ -0,0 +1,139 @@
+package mnemonic
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+	"strings"
+)
+
+// The encoding is the one of BIP-39: the entropy is followed by the first len(entropy)/4 bits of its SHA-256 hash as a
+// checksum, and every 11 bits select a word of the English word list. As MAYO compact secret keys are 24, 32, or 40
+// bytes, the phrases are 18, 24, or 30 words. The parameter set is not encoded, as levels 1 and 2 share the length of
+// their secret keys, thus it must be recorded alongside the phrase.
+
+const (
+	// MinEntropyLength and MaxEntropyLength bound the length of the encoded entropy, which must be a multiple of 4
+	MinEntropyLength = 16
+	MaxEntropyLength = 64
+
+	bitsPerWord = 11
+)
+
+// englishWordList is the English word list of BIP-39
+//
+//go:embed english.txt
+var englishWordList string
+
+var (
+	words       = strings.Fields(englishWordList)
+	wordIndices = indexWords(words)
+)
+
+var (
+	// ErrInvalidEntropyLength is returned when the entropy cannot be encoded as a phrase
+	ErrInvalidEntropyLength = errors.New("mnemonic: entropy must be a multiple of 4 bytes between 16 and 64 bytes")
+
+	// ErrInvalidPhraseLength is returned when a phrase does not have the number of words of any entropy length
+	ErrInvalidPhraseLength = errors.New("mnemonic: invalid number of words")
+
+	// ErrUnknownWord is returned when a phrase holds a word that is not in the word list
+	ErrUnknownWord = errors.New("mnemonic: unknown word")
+
+	// ErrInvalidChecksum is returned when the checksum of a phrase does not match, such as when a word is mistyped
+	ErrInvalidChecksum = errors.New("mnemonic: invalid checksum")
+)
+
+// Encode encodes entropy as a phrase of words separated by spaces
+func Encode(entropy []byte) (string, error) {
+	if len(entropy) < MinEntropyLength || len(entropy) > MaxEntropyLength || len(entropy)%4 != 0 {
+		return "", ErrInvalidEntropyLength
+	}
+
+	// Append the checksum, the checksum has at most 16 bits
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte(nil), entropy...), checksum[:2]...)
+	amountWords := (8*len(entropy) + len(entropy)/4) / bitsPerWord
+
+	phrase := make([]string, amountWords)
+	for i := range phrase {
+		phrase[i] = words[readBits(data, i*bitsPerWord, bitsPerWord)]
+	}
+	return strings.Join(phrase, " "), nil
+}
+
+// Decode decodes a phrase into its entropy, after checking the checksum. Words are separated by whitespace, and are
+// not case-sensitive.
+func Decode(phrase string) ([]byte, error) {
+	phraseWords := strings.Fields(strings.ToLower(phrase))
+
+	// Every 3 words encode 4 bytes of entropy and 1 bit of checksum
+	if len(phraseWords)%3 != 0 {
+		return nil, ErrInvalidPhraseLength
+	}
+	entropyLength := 4 * len(phraseWords) / 3
+	if entropyLength < MinEntropyLength || entropyLength > MaxEntropyLength {
+		return nil, ErrInvalidPhraseLength
+	}
+
+	data := make([]byte, entropyLength+2)
+	for i, word := range phraseWords {
+		index, ok := wordIndices[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: '%s'", ErrUnknownWord, word)
+		}
+		writeBits(data, i*bitsPerWord, bitsPerWord, index)
+	}
+
+	entropy := data[:entropyLength]
+	checksum := sha256.Sum256(entropy)
+	checksumBits := entropyLength / 4
+	if readBits(data, 8*entropyLength, checksumBits) != readBits(checksum[:], 0, checksumBits) {
+		return nil, ErrInvalidChecksum
+	}
+	return entropy, nil
+}
+
+// EncodePrivateKey encodes the compact secret key csk as a phrase
+func EncodePrivateKey(sk *mayo.PrivateKey) (string, error) {
+	return Encode(sk.Bytes())
+}
+
+// DecodePrivateKey decodes a phrase into a compact secret key of the parameter set m
+func DecodePrivateKey(m *mayo.Mayo, phrase string) (*mayo.PrivateKey, error) {
+	csk, err := Decode(phrase)
+	if err != nil {
+		return nil, err
+	}
+	return m.NewPrivateKey(csk)
+}
+
+// readBits reads length bits starting at bit offset, where the most significant bit of a byte comes first
+func readBits(data []byte, offset, length int) int {
+	value := 0
+	for i := offset; i < offset+length; i++ {
+		value = value<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+	return value
+}
+
+// writeBits writes the length least significant bits of value starting at bit offset, see readBits
+func writeBits(data []byte, offset, length, value int) {
+	for i := 0; i < length; i++ {
+		if value>>(length-1-i)&1 == 1 {
+			position := offset + i
+			data[position/8] |= 0x80 >> (position % 8)
+		}
+	}
+}
+
+// indexWords maps every word of the word list to its index
+func indexWords(words []string) map[string]int {
+	indices := make(map[string]int, len(words))
+	for i, word := range words {
+		indices[word] = i
+	}
+	return indices
+}
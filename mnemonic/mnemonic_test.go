@@ -0,0 +1,128 @@
+package mnemonic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mayo-go/mayo"
+	"strings"
+	"testing"
+)
+
+// vectors are test vectors of BIP-39
+var vectors = []struct {
+	entropy string
+	phrase  string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	{
+		"000000000000000000000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon " +
+			"abandon abandon abandon abandon abandon agent",
+	},
+	{
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	},
+}
+
+func TestWordList(t *testing.T) {
+	hash := sha256.Sum256([]byte(englishWordList))
+	if hex.EncodeToString(hash[:]) != "2f5eed53a4727b4bf8880d8f3f199efc90e58503646d9ff8eff3a2ed3b24dbda" {
+		t.Error("Word list does not match the English word list of BIP-39")
+	}
+	if len(words) != 2048 || len(wordIndices) != 2048 {
+		t.Error("Word list should have 2048 distinct words")
+	}
+}
+
+func TestVectors(t *testing.T) {
+	for _, vector := range vectors {
+		entropy, _ := hex.DecodeString(vector.entropy)
+
+		phrase, err := Encode(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if phrase != vector.phrase {
+			t.Error("Wrong phrase for", vector.entropy, "got:", phrase)
+		}
+
+		decoded, err := Decode(vector.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, entropy) {
+			t.Error("Wrong entropy for", vector.phrase, "got:", hex.EncodeToString(decoded))
+		}
+	}
+}
+
+func TestPrivateKeyRoundTrip(t *testing.T) {
+	expectedWords := map[int]int{1: 18, 2: 18, 3: 24, 5: 30}
+
+	for securityLevel, amountWords := range expectedWords {
+		m, err := mayo.InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, sk, err := m.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		phrase, err := EncodePrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(strings.Fields(phrase)) != amountWords {
+			t.Error("Expected", amountWords, "words for security level", securityLevel, "got:", phrase)
+		}
+
+		// Whitespace and case do not matter
+		restored, err := DecodePrivateKey(m, "  "+strings.ToUpper(strings.ReplaceAll(phrase, " ", "\n"))+"\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(restored.Bytes(), sk.Bytes()) || !pk.Equal(restored.Public()) {
+			t.Error("Restored key does not match for security level", securityLevel)
+		}
+	}
+}
+
+func TestDecodeRejectsInvalidPhrases(t *testing.T) {
+	valid := vectors[1].phrase
+
+	// Swapping two words breaks the checksum
+	swapped := strings.Fields(valid)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if _, err := Decode(strings.Join(swapped, " ")); !errors.Is(err, ErrInvalidChecksum) {
+		t.Error("Expected ErrInvalidChecksum, got:", err)
+	}
+
+	if _, err := Decode(strings.Replace(valid, "legal", "legals", 1)); !errors.Is(err, ErrUnknownWord) {
+		t.Error("Expected ErrUnknownWord, got:", err)
+	}
+
+	if _, err := Decode(valid + " zoo"); !errors.Is(err, ErrInvalidPhraseLength) {
+		t.Error("Expected ErrInvalidPhraseLength, got:", err)
+	}
+
+	if _, err := Encode(make([]byte, 18)); !errors.Is(err, ErrInvalidEntropyLength) {
+		t.Error("Expected ErrInvalidEntropyLength, got:", err)
+	}
+
+	// A phrase of another parameter set does not have the length of the secret key
+	m, _ := mayo.InitMayo(3)
+	if _, err := DecodePrivateKey(m, vectors[2].phrase); !errors.Is(err, mayo.ErrInvalidPrivateKey) {
+		t.Error("Expected ErrInvalidPrivateKey, got:", err)
+	}
+}
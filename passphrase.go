@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// passphraseEnvironmentVariable is the environment variable holding the passphrase of encrypted key files, which is
+// used instead of prompting if it is set
+const passphraseEnvironmentVariable = "MAYO_PASSPHRASE"
+
+// readPassphrase reads the passphrase from the environment variable, or prompts for it on the terminal. If confirm is
+// set, the passphrase is prompted for twice, which is used when a key file is encrypted.
+func readPassphrase(confirm bool) ([]byte, error) {
+	if passphrase, ok := os.LookupEnv(passphraseEnvironmentVariable); ok {
+		if passphrase == "" {
+			return nil, fmt.Errorf("%s is empty", passphraseEnvironmentVariable)
+		}
+		return []byte(passphrase), nil
+	}
+
+	// Prompt on the terminal, as stdin may hold the message
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("no terminal to prompt for the passphrase, set %s instead", passphraseEnvironmentVariable)
+	}
+	defer tty.Close()
+
+	passphrase, err := promptPassphrase(tty, "Passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.New("passphrase is empty")
+	}
+
+	if confirm {
+		repeated, err := promptPassphrase(tty, "Repeat passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, repeated) {
+			return nil, errors.New("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// promptPassphrase writes the prompt to the terminal, and reads a line without echoing it
+func promptPassphrase(tty *os.File, prompt string) ([]byte, error) {
+	fmt.Fprint(tty, prompt)
+	passphrase, err := term.ReadPassword(int(tty.Fd()))
+	fmt.Fprintln(tty)
+	return passphrase, err
+}
@@ -0,0 +1,65 @@
+package pki
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"mayo-go/mayo"
+	"sync"
+)
+
+// oids maps the security levels of MAYO to the object identifiers of their algorithm identifiers. The defaults are the
+// provisional identifiers used by the Open Quantum Safe project, as no final identifiers have been assigned yet. Use
+// SetOID to match the assignments of the IETF draft in use.
+var oids = map[int]asn1.ObjectIdentifier{
+	1: {1, 3, 9999, 8, 1, 3},
+	2: {1, 3, 9999, 8, 2, 3},
+	3: {1, 3, 9999, 8, 3, 3},
+	5: {1, 3, 9999, 8, 5, 3},
+}
+
+// oidsMutex guards oids, which SetOID writes while keys may be encoded or parsed concurrently
+var oidsMutex sync.RWMutex
+
+// SetOID sets the object identifier used for the given security level. It may be called concurrently with encoding or
+// parsing keys, which use the identifiers set before they started.
+func SetOID(securityLevel int, oid asn1.ObjectIdentifier) error {
+	if _, err := mayo.InitMayo(securityLevel); err != nil {
+		return err
+	}
+
+	oidsMutex.Lock()
+	defer oidsMutex.Unlock()
+	for level, existing := range oids {
+		if level != securityLevel && existing.Equal(oid) {
+			return fmt.Errorf("pki: oid %s is already used by security level %d", oid, level)
+		}
+	}
+
+	oids[securityLevel] = append(asn1.ObjectIdentifier(nil), oid...)
+	return nil
+}
+
+// OID returns the object identifier used for the given security level
+func OID(securityLevel int) (asn1.ObjectIdentifier, error) {
+	oidsMutex.RLock()
+	defer oidsMutex.RUnlock()
+
+	oid, ok := oids[securityLevel]
+	if !ok {
+		return nil, fmt.Errorf("pki: no oid for security level %d", securityLevel)
+	}
+	return append(asn1.ObjectIdentifier(nil), oid...), nil
+}
+
+// mayoFromOID returns the parameter set identified by the object identifier
+func mayoFromOID(oid asn1.ObjectIdentifier) (*mayo.Mayo, error) {
+	oidsMutex.RLock()
+	defer oidsMutex.RUnlock()
+
+	for level, existing := range oids {
+		if existing.Equal(oid) {
+			return mayo.InitMayo(level)
+		}
+	}
+	return nil, fmt.Errorf("pki: unknown algorithm %s", oid)
+}
@@ -0,0 +1,61 @@
+package pki
+
+import (
+	"encoding/pem"
+	"errors"
+	"mayo-go/mayo"
+)
+
+// Types of the PEM blocks, which are the same as for other algorithms
+const (
+	PublicKeyPEMType  = "PUBLIC KEY"
+	PrivateKeyPEMType = "PRIVATE KEY"
+)
+
+// EncodePublicKeyPEM encodes the public key as a PEM block holding a SubjectPublicKeyInfo
+func EncodePublicKeyPEM(pk *mayo.PublicKey) ([]byte, error) {
+	der, err := MarshalPKIXPublicKey(pk)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PublicKeyPEMType, Bytes: der}), nil
+}
+
+// DecodePublicKeyPEM decodes the first PEM block of data, which must hold a SubjectPublicKeyInfo
+func DecodePublicKeyPEM(data []byte) (*mayo.PublicKey, error) {
+	der, err := decodePEM(data, PublicKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePKIXPublicKey(der)
+}
+
+// EncodePrivateKeyPEM encodes the private key as a PEM block holding a PKCS #8 OneAsymmetricKey
+func EncodePrivateKeyPEM(sk *mayo.PrivateKey, withPublicKey bool) ([]byte, error) {
+	der, err := MarshalPKCS8PrivateKey(sk, withPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: PrivateKeyPEMType, Bytes: der}), nil
+}
+
+// DecodePrivateKeyPEM decodes the first PEM block of data, which must hold a PKCS #8 OneAsymmetricKey
+func DecodePrivateKeyPEM(data []byte) (*mayo.PrivateKey, error) {
+	der, err := decodePEM(data, PrivateKeyPEMType)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePKCS8PrivateKey(der)
+}
+
+// decodePEM decodes the first PEM block of data, and checks that it has the expected type
+func decodePEM(data []byte, blockType string) ([]byte, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("pki: no PEM block found")
+	}
+	if block.Type != blockType {
+		return nil, errors.New("pki: unexpected PEM block type " + block.Type)
+	}
+	return block.Bytes, nil
+}
@@ -0,0 +1,92 @@
+package pki
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"mayo-go/mayo"
+)
+
+// Versions of OneAsymmetricKey, where version 2 is used when the public key is included, as in RFC 5958
+const (
+	pkcs8Version1 = 0
+	pkcs8Version2 = 1
+)
+
+// oneAsymmetricKey is the OneAsymmetricKey structure of RFC 5958 without attributes. The private key is an OCTET
+// STRING wrapping the seed seedSk, which is the compact secret key csk, and the public key is the compact public key
+type oneAsymmetricKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+	PublicKey  asn1.BitString `asn1:"optional,tag:1"`
+}
+
+// MarshalPKCS8PrivateKey encodes the private key as a DER PKCS #8 OneAsymmetricKey. The public key is included if
+// withPublicKey is set, which lets parsers check that the key has not been corrupted
+func MarshalPKCS8PrivateKey(sk *mayo.PrivateKey, withPublicKey bool) ([]byte, error) {
+	algorithm, err := AlgorithmIdentifier(sk.Mayo())
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := asn1.Marshal(sk.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	key := oneAsymmetricKey{
+		Version:    pkcs8Version1,
+		Algorithm:  algorithm,
+		PrivateKey: seed,
+	}
+	if withPublicKey {
+		cpk := sk.Public().(*mayo.PublicKey).Bytes()
+		key.Version = pkcs8Version2
+		key.PublicKey = asn1.BitString{Bytes: cpk, BitLength: 8 * len(cpk)}
+	}
+
+	return asn1.Marshal(key)
+}
+
+// ParsePKCS8PrivateKey decodes a DER PKCS #8 OneAsymmetricKey holding a MAYO private key. If the public key is
+// included, it must match the public key derived from the seed
+func ParsePKCS8PrivateKey(der []byte) (*mayo.PrivateKey, error) {
+	var key oneAsymmetricKey
+	if rest, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("pki: trailing data after private key")
+	}
+
+	if key.Version != pkcs8Version1 && key.Version != pkcs8Version2 {
+		return nil, errors.New("pki: unsupported private key version")
+	}
+	m, err := ParseAlgorithmIdentifier(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	var seed []byte
+	if rest, err := asn1.Unmarshal(key.PrivateKey, &seed); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("pki: trailing data after seed")
+	}
+	sk, err := m.NewPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if key.PublicKey.BitLength > 0 {
+		pk, err := m.NewPublicKey(key.PublicKey.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if !pk.Equal(sk.Public()) {
+			return nil, errors.New("pki: public key does not match private key")
+		}
+	}
+
+	return sk, nil
+}
@@ -0,0 +1,193 @@
+package pki
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"mayo-go/mayo"
+	"sync"
+	"testing"
+)
+
+var securityLevels = []int{1, 2, 3, 5}
+
+func TestPublicKeyRoundTrip(t *testing.T) {
+	for _, level := range securityLevels {
+		m, _ := mayo.InitMayo(level)
+		pk, _, err := m.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encoded, err := EncodePublicKeyPEM(pk)
+		if err != nil {
+			t.Fatal(level, err)
+		}
+		decoded, err := DecodePublicKeyPEM(encoded)
+		if err != nil {
+			t.Fatal(level, err)
+		}
+
+		if !pk.Equal(decoded) {
+			t.Error("Public key did not survive round trip", level)
+		}
+		if decoded.Mayo().SecurityLevel() != level {
+			t.Error("Decoded public key has wrong security level", level, decoded.Mayo().SecurityLevel())
+		}
+	}
+}
+
+func TestPrivateKeyRoundTrip(t *testing.T) {
+	for _, level := range securityLevels {
+		m, _ := mayo.InitMayo(level)
+		pk, sk, err := m.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, withPublicKey := range []bool{false, true} {
+			encoded, err := EncodePrivateKeyPEM(sk, withPublicKey)
+			if err != nil {
+				t.Fatal(level, err)
+			}
+			decoded, err := DecodePrivateKeyPEM(encoded)
+			if err != nil {
+				t.Fatal(level, err)
+			}
+
+			if !bytes.Equal(sk.Bytes(), decoded.Bytes()) {
+				t.Error("Private key did not survive round trip", level, withPublicKey)
+			}
+			if decoded.Mayo().SecurityLevel() != level {
+				t.Error("Decoded private key has wrong security level", level, decoded.Mayo().SecurityLevel())
+			}
+			if !pk.Equal(decoded.Public()) {
+				t.Error("Decoded private key has wrong public key", level, withPublicKey)
+			}
+		}
+	}
+}
+
+func TestPrivateKeyWithMismatchingPublicKeyIsRejected(t *testing.T) {
+	m, _ := mayo.InitMayo(2)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPk, _, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := MarshalPKCS8PrivateKey(sk, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key oneAsymmetricKey
+	if _, err = asn1.Unmarshal(der, &key); err != nil {
+		t.Fatal(err)
+	}
+	key.Version = pkcs8Version2
+	key.PublicKey = asn1.BitString{Bytes: otherPk.Bytes(), BitLength: 8 * len(otherPk.Bytes())}
+	der, err = asn1.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ParsePKCS8PrivateKey(der); err == nil {
+		t.Error("Expected error for mismatching public key")
+	}
+}
+
+func TestPrivateKeyWithoutPublicKeyOmitsField(t *testing.T) {
+	m, _ := mayo.InitMayo(1)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := MarshalPKCS8PrivateKey(sk, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// SEQUENCE { INTEGER 0, SEQUENCE { OID }, OCTET STRING { OCTET STRING seed } }
+	oid, _ := OID(1)
+	encodedOID, _ := asn1.Marshal(oid)
+	expectedLength := 2 + 3 + 2 + len(encodedOID) + 2 + 2 + len(sk.Bytes())
+	if len(der) != expectedLength {
+		t.Error("Unexpected length of private key without public key", len(der), expectedLength)
+	}
+}
+
+func TestSetOIDConcurrently(t *testing.T) {
+	original, _ := OID(1)
+	defer func() { _ = SetOID(1, original) }()
+
+	// Run with -race to detect unsynchronized access to the identifiers
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_ = SetOID(1, original)
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := mayoFromOID(original); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSetOID(t *testing.T) {
+	original, _ := OID(5)
+	defer func() { _ = SetOID(5, original) }()
+
+	custom := asn1.ObjectIdentifier{1, 2, 3, 4}
+	if err := SetOID(5, custom); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := mayo.InitMayo(5)
+	pk, _, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := MarshalPKIXPublicKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedOID, _ := asn1.Marshal(custom)
+	if !bytes.Contains(der, encodedOID) {
+		t.Error("Encoded public key does not contain the custom oid")
+	}
+	if _, err = ParsePKIXPublicKey(der); err != nil {
+		t.Error(err)
+	}
+
+	if err = SetOID(4, custom); err == nil {
+		t.Error("Expected error for unknown security level")
+	}
+	level1, _ := OID(1)
+	if err = SetOID(5, level1); err == nil {
+		t.Error("Expected error for oid used by another security level")
+	}
+}
+
+func TestDecodeRejectsWrongBlockType(t *testing.T) {
+	m, _ := mayo.InitMayo(2)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := EncodePrivateKeyPEM(sk, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = DecodePublicKeyPEM(encoded); err == nil {
+		t.Error("Expected error when decoding private key as public key")
+	}
+}
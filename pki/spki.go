@@ -0,0 +1,67 @@
+package pki
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"mayo-go/mayo"
+)
+
+// subjectPublicKeyInfo is the SubjectPublicKeyInfo structure of RFC 5280, where the public key is the compact public
+// key cpk and the algorithm identifier has no parameters
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MarshalPKIXPublicKey encodes the public key as a DER SubjectPublicKeyInfo
+func MarshalPKIXPublicKey(pk *mayo.PublicKey) ([]byte, error) {
+	algorithm, err := AlgorithmIdentifier(pk.Mayo())
+	if err != nil {
+		return nil, err
+	}
+
+	cpk := pk.Bytes()
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithm,
+		PublicKey: asn1.BitString{Bytes: cpk, BitLength: 8 * len(cpk)},
+	})
+}
+
+// ParsePKIXPublicKey decodes a DER SubjectPublicKeyInfo holding a MAYO public key
+func ParsePKIXPublicKey(der []byte) (*mayo.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("pki: trailing data after public key")
+	}
+
+	m, err := ParseAlgorithmIdentifier(info.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if info.PublicKey.BitLength != 8*len(info.PublicKey.Bytes) {
+		return nil, errors.New("pki: public key is not a whole number of bytes")
+	}
+
+	return m.NewPublicKey(info.PublicKey.Bytes)
+}
+
+// AlgorithmIdentifier returns the algorithm identifier of the parameter set, which has absent parameters. It identifies
+// both MAYO keys and MAYO signatures
+func AlgorithmIdentifier(m *mayo.Mayo) (pkix.AlgorithmIdentifier, error) {
+	oid, err := OID(m.SecurityLevel())
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oid}, nil
+}
+
+// ParseAlgorithmIdentifier returns the parameter set of the algorithm identifier, which must have absent parameters
+func ParseAlgorithmIdentifier(algorithm pkix.AlgorithmIdentifier) (*mayo.Mayo, error) {
+	if len(algorithm.Parameters.FullBytes) > 0 {
+		return nil, errors.New("pki: algorithm identifier must not have parameters")
+	}
+	return mayoFromOID(algorithm.Algorithm)
+}
@@ -0,0 +1,95 @@
+package rand
+
+import (
+	"crypto/aes"
+	"errors"
+)
+
+// SeedLength is the length of the entropy input and personalization string of the CTR-DRBG
+const SeedLength = 48
+
+// CTRDRBG is the AES-256 CTR-DRBG from the NIST reference implementation (randombytes_ctrdrbg.c), which is used to
+// generate the known answer tests. It matches randombytes_init and randombytes bit for bit. Its output is fully
+// determined by the seed, thus it must never be used as a source of randomness in production. Each instance holds its
+// own state, and a single instance must not be read from concurrently.
+type CTRDRBG struct {
+	key           [32]byte
+	v             [16]byte
+	reseedCounter int
+}
+
+// NewCTRDRBG corresponds to randombytes_init, and returns a CTR-DRBG seeded with the 48 byte entropy input and the
+// optional (nil) 48 byte personalization string
+func NewCTRDRBG(entropyInput, personalizationString []byte) (*CTRDRBG, error) {
+	if len(entropyInput) != SeedLength {
+		return nil, errors.New("rand: entropy input must be 48 bytes")
+	}
+	if personalizationString != nil && len(personalizationString) != SeedLength {
+		return nil, errors.New("rand: personalization string must be 48 bytes")
+	}
+
+	var seedMaterial [SeedLength]byte
+	copy(seedMaterial[:], entropyInput)
+	for i := range personalizationString {
+		seedMaterial[i] ^= personalizationString[i]
+	}
+
+	d := &CTRDRBG{}
+	d.update(seedMaterial[:])
+	d.reseedCounter = 1
+	return d, nil
+}
+
+// Read corresponds to a single call of randombytes, filling p. Note that the state is updated after every call, such
+// that the output depends on how the requested bytes are split over calls.
+func (d *CTRDRBG) Read(p []byte) (int, error) {
+	block, err := aes.NewCipher(d.key[:])
+	if err != nil {
+		return 0, err
+	}
+
+	var buffer [16]byte
+	for i := 0; i < len(p); i += 16 {
+		incrementCounter(&d.v)
+		block.Encrypt(buffer[:], d.v[:])
+		copy(p[i:], buffer[:])
+	}
+
+	d.update(nil)
+	d.reseedCounter++
+
+	return len(p), nil
+}
+
+// update corresponds to AES256_CTR_DRBG_Update, providedData is either nil or 48 bytes
+func (d *CTRDRBG) update(providedData []byte) {
+	// The key is always 32 bytes, thus NewCipher cannot fail
+	block, _ := aes.NewCipher(d.key[:])
+
+	var temp [48]byte
+	for i := 0; i < 3; i++ {
+		incrementCounter(&d.v)
+		block.Encrypt(temp[16*i:16*(i+1)], d.v[:])
+	}
+
+	if providedData != nil {
+		for i := range temp {
+			temp[i] ^= providedData[i]
+		}
+	}
+
+	copy(d.key[:], temp[:32])
+	copy(d.v[:], temp[32:])
+}
+
+// incrementCounter increments v as a 128-bit big-endian integer
+func incrementCounter(v *[16]byte) {
+	for j := 15; j >= 0; j-- {
+		if v[j] == 0xff {
+			v[j] = 0x00
+		} else {
+			v[j]++
+			break
+		}
+	}
+}
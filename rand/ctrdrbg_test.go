@@ -0,0 +1,84 @@
+package rand
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestCTRDRBGMatchesKatSeeds checks the DRBG against the seeds in the KAT files, which PQCgenKAT_sign generates by
+// initializing the DRBG with the entropy input 0, 1, ..., 47 and reading a 48 byte seed and a 33*(i+1) byte message
+// per test vector
+func TestCTRDRBGMatchesKatSeeds(t *testing.T) {
+	expectedSeeds := []string{
+		"061550234D158C5EC95595FE04EF7A25767F2E24CC2BC479D09D86DC9ABCFDE7056A8C266F9EF97ED08541DBD2E1FFA1",
+		"64335BF29E5DE62842C941766BA129B0643B5E7121CA26CFC190EC7DC3543830557FDD5C03CF123A456D48EFEA43C868",
+		"BFF58FDA9DB4C2D8BD02E4647868D4A2FA12500A65CA4C9F918B505707FA775951018D9149C97D443EA16B07DD68435B",
+	}
+
+	entropyInput := make([]byte, 48)
+	for i := range entropyInput {
+		entropyInput[i] = byte(i)
+	}
+
+	drbg, err := NewCTRDRBG(entropyInput, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expectedSeed := range expectedSeeds {
+		expected, _ := hex.DecodeString(expectedSeed)
+		seed := make([]byte, 48)
+		_, _ = drbg.Read(seed)
+
+		if !bytes.Equal(seed, expected) {
+			t.Error("Seed does not match KAT seed", i, hex.EncodeToString(seed), expectedSeed)
+		}
+
+		message := make([]byte, 33*(i+1))
+		_, _ = drbg.Read(message)
+	}
+}
+
+// TestCTRDRBGMatchesKatSecretKey checks that the first bytes after seeding with a KAT seed, are the compact secret key
+func TestCTRDRBGMatchesKatSecretKey(t *testing.T) {
+	seed, _ := hex.DecodeString("061550234D158C5EC95595FE04EF7A25767F2E24CC2BC479D09D86DC9ABCFDE7056A8C266F9EF97ED08541DBD2E1FFA1")
+	expected, _ := hex.DecodeString("7C9935A0B07694AA0C6D10E4DB6B1ADD2FD81A25CCB14803")
+
+	drbg, err := NewCTRDRBG(seed, make([]byte, 48))
+	if err != nil {
+		t.Fatal(err)
+	}
+	csk := make([]byte, 24)
+	_, _ = drbg.Read(csk)
+
+	if !bytes.Equal(csk, expected) {
+		t.Error("Output does not match KAT secret key", hex.EncodeToString(csk))
+	}
+}
+
+func TestCTRDRBGInstancesAreIndependent(t *testing.T) {
+	seed := make([]byte, 48)
+	a, _ := NewCTRDRBG(seed, nil)
+	b, _ := NewCTRDRBG(seed, nil)
+
+	// Reading from a must not advance b
+	first := make([]byte, 32)
+	_, _ = a.Read(first)
+	_, _ = a.Read(make([]byte, 32))
+
+	other := make([]byte, 32)
+	_, _ = b.Read(other)
+	if !bytes.Equal(first, other) {
+		t.Error("Instances with the same seed should produce the same output")
+	}
+}
+
+func TestNewCTRDRBGRejectsWrongLengths(t *testing.T) {
+	if _, err := NewCTRDRBG(make([]byte, 32), nil); err == nil {
+		t.Error("Expected error for short entropy input")
+	}
+	if _, err := NewCTRDRBG(make([]byte, 48), make([]byte, 16)); err == nil {
+		t.Error("Expected error for short personalization string")
+	}
+}
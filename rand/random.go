@@ -1,30 +1,25 @@
 package rand
 
-/*
-#include <stdlib.h>
-#include <stdio.h>
-#include "randombytes.h"
-*/
-import "C"
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	cryptorand "crypto/rand"
 	"crypto/sha3"
-	"unsafe"
+	"io"
 )
 
-func InitRandomness(entropyInput []byte, personalizationString []byte, securityStrength int) {
-	C.randombytes_init(
-		(*C.uchar)(unsafe.Pointer(&entropyInput[0])),
-		(*C.uchar)(unsafe.Pointer(&personalizationString[0])),
-		C.int(securityStrength),
-	)
-}
+// SampleRandomBytes outputs length random bytes read from reader, or an error if the reader fails. If reader is nil,
+// the entropy of the operating system is read through crypto/rand.
+func SampleRandomBytes(reader io.Reader, length int) ([]byte, error) {
+	if reader == nil {
+		reader = cryptorand.Reader
+	}
 
-func SampleRandomBytes(length int) []byte {
 	value := make([]byte, length)
-	C.randombytes((*C.uchar)(unsafe.Pointer(&value[0])), C.size_t(length))
-	return value
+	if _, err := io.ReadFull(reader, value); err != nil {
+		return nil, err
+	}
+	return value, nil
 }
 
 func Aes128ctr(seed []byte, l int) []byte {
@@ -47,3 +42,17 @@ func Shake256(outputLength int, inputs ...[]byte) []byte {
 
 	return output
 }
+
+// CShake256 outputs cSHAKE256 of the concatenated inputs with the customization string, see NIST SP 800-185. For a
+// non-empty customization string the output is domain separated from Shake256 of any input.
+func CShake256(outputLength int, customization string, inputs ...[]byte) []byte {
+	output := make([]byte, outputLength)
+
+	h := sha3.NewCSHAKE256(nil, []byte(customization))
+	for _, input := range inputs {
+		_, _ = h.Write(input)
+	}
+	_, _ = h.Read(output)
+
+	return output
+}
@@ -0,0 +1,63 @@
+package rand
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("failing reader")
+}
+
+func TestSampleRandomBytesUsesReader(t *testing.T) {
+	reader := bytes.NewReader([]byte{1, 2, 3, 4})
+
+	value, err := SampleRandomBytes(reader, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, []byte{1, 2, 3, 4}) {
+		t.Error("Expected bytes from the reader, got:", value)
+	}
+
+	if _, err = SampleRandomBytes(reader, 1); err == nil {
+		t.Error("Expected error when the reader is exhausted")
+	}
+}
+
+func TestSampleRandomBytesReturnsReaderError(t *testing.T) {
+	if _, err := SampleRandomBytes(failingReader{}, 16); err == nil {
+		t.Error("Expected error from failing reader")
+	}
+}
+
+func TestDefaultReaderIsNotDeterministic(t *testing.T) {
+	a, err := SampleRandomBytes(nil, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := SampleRandomBytes(nil, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a, b) {
+		t.Error("Two samples from the default reader should differ")
+	}
+}
+
+func TestCShake256(t *testing.T) {
+	// Sample #3 of the cSHAKE examples of NIST
+	expected := "d008828e2b80ac9d2218ffee1d070c48b8e4c87bff32c9699d5b6896eee0edd1" +
+		"64020e2be0560858d9c00c037e34a96937c561a74c412bb4c746469527281c8c"
+	if output := hex.EncodeToString(CShake256(64, "Email Signature", []byte{0, 1, 2, 3})); output != expected {
+		t.Error("cSHAKE256 output is wrong", output)
+	}
+
+	if bytes.Equal(CShake256(32, "customization", []byte("message")), Shake256(32, []byte("message"))) {
+		t.Error("cSHAKE256 should differ from SHAKE256")
+	}
+}
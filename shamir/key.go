@@ -0,0 +1,76 @@
+package shamir
+
+import (
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mayo-go/mayo"
+	"strconv"
+)
+
+// SharePEMType is the type of PEM blocks holding a share of a secret key
+const SharePEMType = "MAYO SECRET KEY SHARE"
+
+// ErrKeyMismatch is returned when the combined secret key does not belong to the expected public key, which is the
+// case if a share was modified or belongs to another key
+var ErrKeyMismatch = errors.New("shamir: combined secret key does not match the public key")
+
+// SplitPrivateKey splits the compact secret key csk into n shares, of which threshold recover the key, see Split
+func SplitPrivateKey(random io.Reader, sk *mayo.PrivateKey, threshold, n int) ([]Share, error) {
+	return Split(random, sk.Bytes(), threshold, n)
+}
+
+// CombinePrivateKey recovers the compact secret key from shares, and checks its integrity by deriving the public key
+// from it, which must equal pk
+func CombinePrivateKey(pk *mayo.PublicKey, shares []Share) (*mayo.PrivateKey, error) {
+	csk, err := Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	sk, err := pk.Mayo().NewPrivateKey(csk)
+	if err != nil {
+		return nil, err
+	}
+	if !pk.Equal(sk.Public()) {
+		return nil, ErrKeyMismatch
+	}
+	return sk, nil
+}
+
+// EncodeSharePEM encodes a share of a secret key of the given security level as a PEM block, whose headers hold the
+// security level, threshold and index of the share
+func EncodeSharePEM(share Share, securityLevel int) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type: SharePEMType,
+		Headers: map[string]string{
+			"Security-Level": strconv.Itoa(securityLevel),
+			"Threshold":      strconv.Itoa(share.Threshold),
+			"Index":          strconv.Itoa(int(share.Index)),
+		},
+		Bytes: share.Value,
+	})
+}
+
+// DecodeSharePEM decodes the first PEM block of data, which must hold a share, and returns it with its security level
+func DecodeSharePEM(data []byte) (Share, int, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != SharePEMType {
+		return Share{}, 0, errors.New("shamir: no share PEM block found")
+	}
+
+	var values [3]int
+	for i, header := range []string{"Security-Level", "Threshold", "Index"} {
+		value, err := strconv.Atoi(block.Headers[header])
+		if err != nil {
+			return Share{}, 0, fmt.Errorf("shamir: invalid %s header of share", header)
+		}
+		values[i] = value
+	}
+	if values[2] < 1 || values[2] > MaxShares {
+		return Share{}, 0, errors.New("shamir: invalid index of share")
+	}
+
+	return Share{Threshold: values[1], Index: byte(values[2]), Value: block.Bytes}, values[0], nil
+}
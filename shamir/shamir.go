@@ -0,0 +1,119 @@
+package shamir
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mayo-go/rand"
+)
+
+// MaxShares is the maximum amount of shares, as every share is a distinct non-zero element of GF(256)
+const MaxShares = 255
+
+var (
+	// ErrNotEnoughShares is returned when fewer shares than the threshold are combined
+	ErrNotEnoughShares = errors.New("shamir: not enough shares")
+
+	// ErrInconsistentShares is returned when shares do not belong to the same split
+	ErrInconsistentShares = errors.New("shamir: shares are inconsistent")
+)
+
+// Share is a single share of a secret, which is the evaluation at Index of the polynomials of the split. Threshold is
+// the amount of shares needed to recover the secret.
+type Share struct {
+	Threshold int
+	Index     byte
+	Value     []byte
+}
+
+// Split splits secret into n shares, such that any threshold of them recover the secret and fewer reveal nothing about
+// it. Every byte of the secret is the constant term of a random polynomial over GF(256) of degree threshold-1, whose
+// coefficients are read from random, or from the entropy of the operating system if random is nil.
+func Split(random io.Reader, secret []byte, threshold, n int) ([]Share, error) {
+	if threshold < 2 || threshold > n || n > MaxShares {
+		return nil, fmt.Errorf("shamir: threshold must be at least 2 and at most the amount of shares, which is at "+
+			"most %d", MaxShares)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("shamir: secret is empty")
+	}
+
+	// coefficients[i] holds the coefficients of degree 1 to threshold-1 of the polynomial of byte i
+	coefficients, err := rand.SampleRandomBytes(random, len(secret)*(threshold-1))
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]Share, n)
+	for s := range shares {
+		x := byte(s + 1)
+		shares[s] = Share{Threshold: threshold, Index: x, Value: make([]byte, len(secret))}
+
+		// Evaluate the polynomials at x using Horner's method
+		for i := range secret {
+			polynomial := coefficients[i*(threshold-1) : (i+1)*(threshold-1)]
+			var y byte
+			for d := len(polynomial) - 1; d >= 0; d-- {
+				y = mul(y, x) ^ polynomial[d]
+			}
+			shares[s].Value[i] = mul(y, x) ^ secret[i]
+		}
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from at least threshold shares of the same split, using Lagrange interpolation at zero.
+// Note that a modified share results in a wrong secret, which must be checked by the caller, see CombinePrivateKey.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 || len(shares) < shares[0].Threshold {
+		return nil, ErrNotEnoughShares
+	}
+
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if share.Threshold != shares[0].Threshold || len(share.Value) != len(shares[0].Value) {
+			return nil, ErrInconsistentShares
+		}
+		if share.Index == 0 || seen[share.Index] {
+			return nil, fmt.Errorf("%w: index %d is zero or used twice", ErrInconsistentShares, share.Index)
+		}
+		seen[share.Index] = true
+	}
+
+	secret := make([]byte, len(shares[0].Value))
+	for i, share := range shares {
+		// The Lagrange basis polynomial of share at zero is the product of x_j / (x_j - x_i), where - is +
+		basis := byte(1)
+		for j, other := range shares {
+			if i != j {
+				basis = mul(basis, mul(other.Index, inverse(other.Index^share.Index)))
+			}
+		}
+
+		for k := range secret {
+			secret[k] ^= mul(basis, share.Value[k])
+		}
+	}
+	return secret, nil
+}
+
+// mul multiplies a and b in GF(256) modulo x^8 + x^4 + x^3 + x + 1, in constant time
+func mul(a, b byte) byte {
+	var product byte
+	for i := 0; i < 8; i++ {
+		product ^= -(b & 1) & a
+		a = a<<1 ^ -(a>>7)&0x1b
+		b >>= 1
+	}
+	return product
+}
+
+// inverse computes the multiplicative inverse of a non-zero a in GF(256) as a^254, in constant time
+func inverse(a byte) byte {
+	result := byte(1)
+	for i := 0; i < 7; i++ {
+		a = mul(a, a)
+		result = mul(result, a)
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package shamir
+
+import (
+	"bytes"
+	"errors"
+	"mayo-go/mayo"
+	"testing"
+)
+
+func TestFieldArithmetic(t *testing.T) {
+	// 0x53 and 0xca are inverses in the field of AES
+	if mul(0x53, 0xca) != 0x01 || inverse(0x53) != 0xca {
+		t.Error("Multiplication or inversion is wrong", mul(0x53, 0xca), inverse(0x53))
+	}
+	if mul(0x57, 0x83) != 0xc1 {
+		t.Error("Multiplication is wrong", mul(0x57, 0x83))
+	}
+
+	for a := 1; a < 256; a++ {
+		if mul(byte(a), inverse(byte(a))) != 1 {
+			t.Error("Inverse is wrong for", a)
+		}
+	}
+}
+
+func TestSplitAndCombineSubsets(t *testing.T) {
+	secret := []byte("a secret of twenty-four!")
+	shares, err := Split(nil, secret, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every subset of at least 3 shares recovers the secret
+	for subset := 0; subset < 1<<len(shares); subset++ {
+		var selected []Share
+		for i := range shares {
+			if subset&(1<<i) != 0 {
+				selected = append(selected, shares[i])
+			}
+		}
+
+		combined, err := Combine(selected)
+		if len(selected) < 3 {
+			if !errors.Is(err, ErrNotEnoughShares) {
+				t.Error("Expected ErrNotEnoughShares for", len(selected), "shares, got:", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(combined, secret) {
+			t.Error("Subset", subset, "did not recover the secret")
+		}
+	}
+}
+
+func TestCombineRejectsInconsistentShares(t *testing.T) {
+	shares, err := Split(nil, []byte("a secret of twenty-four!"), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = Combine([]Share{shares[0], shares[0]}); !errors.Is(err, ErrInconsistentShares) {
+		t.Error("Expected ErrInconsistentShares for a duplicate share, got:", err)
+	}
+
+	truncated := Share{Threshold: 2, Index: shares[1].Index, Value: shares[1].Value[1:]}
+	if _, err = Combine([]Share{shares[0], truncated}); !errors.Is(err, ErrInconsistentShares) {
+		t.Error("Expected ErrInconsistentShares for shares of different lengths, got:", err)
+	}
+
+	if _, err = Split(nil, []byte("secret"), 1, 3); err == nil {
+		t.Error("Expected error for a threshold of 1")
+	}
+	if _, err = Split(nil, []byte("secret"), 4, 3); err == nil {
+		t.Error("Expected error for a threshold above the amount of shares")
+	}
+}
+
+func TestSplitAndCombinePrivateKey(t *testing.T) {
+	for _, securityLevel := range []int{1, 2, 3, 5} {
+		m, err := mayo.InitMayo(securityLevel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pk, sk, err := m.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shares, err := SplitPrivateKey(nil, sk, 2, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Round trip the shares through PEM
+		decoded := make([]Share, len(shares))
+		for i, share := range shares {
+			var level int
+			decoded[i], level, err = DecodeSharePEM(EncodeSharePEM(share, securityLevel))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if level != securityLevel {
+				t.Error("Security level not decoded correctly", level)
+			}
+		}
+
+		combined, err := CombinePrivateKey(pk, decoded[1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(combined.Bytes(), sk.Bytes()) {
+			t.Error("Combined key does not match for security level", securityLevel)
+		}
+
+		// A modified share results in a key that does not match the public key
+		decoded[0].Value[0] ^= 1
+		if _, err = CombinePrivateKey(pk, decoded[:2]); !errors.Is(err, ErrKeyMismatch) {
+			t.Error("Expected ErrKeyMismatch, got:", err)
+		}
+	}
+}
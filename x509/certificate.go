@@ -0,0 +1,245 @@
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"mayo-go/mayo"
+	"mayo-go/pki"
+	"time"
+)
+
+// Certificate is an X.509 v3 certificate, whose subject public key and signature are MAYO
+type Certificate struct {
+	Raw                     []byte // Complete DER encoding of the certificate
+	RawTBSCertificate       []byte // DER encoding of the TBSCertificate, which is the signed message
+	RawSubjectPublicKeyInfo []byte
+	RawSubject              []byte
+	RawIssuer               []byte
+
+	SerialNumber        *big.Int
+	Issuer              pkix.Name
+	Subject             pkix.Name
+	NotBefore, NotAfter time.Time
+
+	PublicKey *mayo.PublicKey
+	Signature []byte
+
+	// SignatureAlgorithm identifies the parameter set of the signature of a parsed certificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+
+	// BasicConstraintsValid indicates if the basic constraints extension is present, in which case IsCA is encoded
+	BasicConstraintsValid bool
+	IsCA                  bool
+	KeyUsage              KeyUsage
+	SubjectKeyId          []byte
+	AuthorityKeyId        []byte
+
+	// Extensions contains all extensions of a parsed certificate, while ExtraExtensions are added when creating one
+	Extensions      []pkix.Extension
+	ExtraExtensions []pkix.Extension
+
+	// UnhandledCriticalExtensions contains the critical extensions that were not understood, which fail verification
+	UnhandledCriticalExtensions []asn1.ObjectIdentifier
+}
+
+// certificate is the Certificate structure of RFC 5280
+type certificate struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// tbsCertificate is the TBSCertificate structure of RFC 5280
+type tbsCertificate struct {
+	Raw                  asn1.RawContent
+	Version              int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber         *big.Int
+	Signature            pkix.AlgorithmIdentifier
+	Issuer               asn1.RawValue
+	Validity             validity
+	Subject              asn1.RawValue
+	SubjectPublicKeyInfo asn1.RawValue
+	IssuerUniqueId       asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId      asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions           []pkix.Extension `asn1:"omitempty,optional,explicit,tag:3"`
+}
+
+type validity struct {
+	NotBefore, NotAfter time.Time
+}
+
+// version3 is the encoded version of X.509 v3 certificates
+const version3 = 2
+
+// CreateCertificate creates a DER encoded certificate for pub based on template, which is signed by priv. The issuer
+// is the subject of parent, and for a self-signed certificate parent is template and pub is the public key of priv.
+// The fields SerialNumber, Subject, NotBefore, NotAfter, BasicConstraintsValid, IsCA, KeyUsage, SubjectKeyId and
+// ExtraExtensions of template are used. If the SubjectKeyId is empty it is computed from pub, and the AuthorityKeyId
+// is taken from parent.
+func CreateCertificate(template, parent *Certificate, pub *mayo.PublicKey, priv *mayo.PrivateKey) ([]byte, error) {
+	if template.SerialNumber == nil {
+		return nil, errors.New("x509: no serial number in template")
+	}
+	if template.SerialNumber.Sign() < 0 {
+		return nil, errors.New("x509: serial number must be non-negative")
+	}
+
+	// The private key must belong to the issuer, which is only known up front if parent is not self-signed
+	issuerKey := parent.PublicKey
+	if parent == template {
+		issuerKey = pub
+	}
+	if issuerKey == nil || !issuerKey.Equal(priv.Public()) {
+		return nil, errors.New("x509: private key does not match the public key of the issuer")
+	}
+
+	spki, err := pki.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := pki.AlgorithmIdentifier(priv.Mayo())
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := marshalName(template.RawSubject, template.Subject)
+	if err != nil {
+		return nil, err
+	}
+	issuer := subject
+	if parent != template {
+		if issuer, err = marshalName(parent.RawSubject, parent.Subject); err != nil {
+			return nil, err
+		}
+	}
+
+	subjectKeyId := template.SubjectKeyId
+	if len(subjectKeyId) == 0 {
+		subjectKeyId = keyIdentifier(pub)
+	}
+	authorityKeyId := parent.SubjectKeyId
+	if parent == template {
+		authorityKeyId = subjectKeyId
+	}
+	extensions, err := buildExtensions(template, subjectKeyId, authorityKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	tbs, err := asn1.Marshal(tbsCertificate{
+		Version:              version3,
+		SerialNumber:         template.SerialNumber,
+		Signature:            algorithm,
+		Issuer:               asn1.RawValue{FullBytes: issuer},
+		Validity:             validity{NotBefore: template.NotBefore.UTC(), NotAfter: template.NotAfter.UTC()},
+		Subject:              asn1.RawValue{FullBytes: subject},
+		SubjectPublicKeyInfo: asn1.RawValue{FullBytes: spki},
+		Extensions:           extensions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := priv.Sign(rand.Reader, tbs, crypto.Hash(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificate{
+		TBSCertificate:     asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm: algorithm,
+		SignatureValue:     asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+}
+
+// ParseCertificate parses a DER encoded certificate, whose subject public key and signature are MAYO
+func ParseCertificate(der []byte) (*Certificate, error) {
+	var cert certificate
+	if rest, err := asn1.Unmarshal(der, &cert); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("x509: trailing data after certificate")
+	}
+
+	var tbs tbsCertificate
+	if rest, err := asn1.Unmarshal(cert.TBSCertificate.FullBytes, &tbs); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("x509: trailing data after TBSCertificate")
+	}
+
+	if tbs.Version != version3 {
+		return nil, errors.New("x509: only version 3 certificates are supported")
+	}
+	if !cert.SignatureAlgorithm.Algorithm.Equal(tbs.Signature.Algorithm) {
+		return nil, errors.New("x509: signature algorithm does not match the algorithm of the TBSCertificate")
+	}
+	if cert.SignatureValue.BitLength != 8*len(cert.SignatureValue.Bytes) {
+		return nil, errors.New("x509: signature is not a whole number of bytes")
+	}
+
+	publicKey, err := pki.ParsePKIXPublicKey(tbs.SubjectPublicKeyInfo.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Certificate{
+		Raw:                     der,
+		RawTBSCertificate:       tbs.Raw,
+		RawSubjectPublicKeyInfo: tbs.SubjectPublicKeyInfo.FullBytes,
+		RawSubject:              tbs.Subject.FullBytes,
+		RawIssuer:               tbs.Issuer.FullBytes,
+		SerialNumber:            tbs.SerialNumber,
+		NotBefore:               tbs.Validity.NotBefore,
+		NotAfter:                tbs.Validity.NotAfter,
+		PublicKey:               publicKey,
+		Signature:               cert.SignatureValue.Bytes,
+		SignatureAlgorithm:      cert.SignatureAlgorithm,
+		Extensions:              tbs.Extensions,
+	}
+
+	if err = parseName(c.RawSubject, &c.Subject); err != nil {
+		return nil, err
+	}
+	if err = parseName(c.RawIssuer, &c.Issuer); err != nil {
+		return nil, err
+	}
+	if err = parseExtensions(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Equal reports whether the certificates have the same DER encoding
+func (c *Certificate) Equal(other *Certificate) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+	return bytes.Equal(c.Raw, other.Raw)
+}
+
+// marshalName returns raw if it is set, and otherwise the DER encoding of name
+func marshalName(raw []byte, name pkix.Name) ([]byte, error) {
+	if len(raw) > 0 {
+		return raw, nil
+	}
+	return asn1.Marshal(name.ToRDNSequence())
+}
+
+// parseName parses the DER encoding of a name into name
+func parseName(raw []byte, name *pkix.Name) error {
+	var rdns pkix.RDNSequence
+	if rest, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return errors.New("x509: trailing data after name")
+	}
+	name.FillFromRDNSequence(&rdns)
+	return nil
+}
@@ -0,0 +1,199 @@
+package x509
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"mayo-go/mayo"
+	"mayo-go/pki"
+)
+
+// oidExtensionRequest is the PKCS #9 attribute which holds the extensions requested in a CSR
+var oidExtensionRequest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 14}
+
+// CertificateRequest is a PKCS #10 certificate signing request, whose public key and self-signature are MAYO
+type CertificateRequest struct {
+	Raw                      []byte // Complete DER encoding of the request
+	RawTBSCertificateRequest []byte // DER encoding of the CertificationRequestInfo, which is the signed message
+	RawSubjectPublicKeyInfo  []byte
+	RawSubject               []byte
+
+	Version   int
+	Subject   pkix.Name
+	PublicKey *mayo.PublicKey
+	Signature []byte
+
+	// Extensions contains the requested extensions of a parsed request, while ExtraExtensions are requested when
+	// creating one
+	Extensions      []pkix.Extension
+	ExtraExtensions []pkix.Extension
+}
+
+// certificationRequest is the CertificationRequest structure of RFC 2986
+type certificationRequest struct {
+	CertificationRequestInfo asn1.RawValue
+	SignatureAlgorithm       pkix.AlgorithmIdentifier
+	SignatureValue           asn1.BitString
+}
+
+// certificationRequestInfo is the CertificationRequestInfo structure of RFC 2986
+type certificationRequestInfo struct {
+	Raw                  asn1.RawContent
+	Version              int
+	Subject              asn1.RawValue
+	SubjectPublicKeyInfo asn1.RawValue
+	Attributes           []asn1.RawValue `asn1:"tag:0"`
+}
+
+// attribute is the Attribute structure of RFC 2986
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// CreateCertificateRequest creates a DER encoded certificate signing request for the public key of priv, which is
+// self-signed by priv. The fields Subject, RawSubject and ExtraExtensions of template are used.
+func CreateCertificateRequest(template *CertificateRequest, priv *mayo.PrivateKey) ([]byte, error) {
+	spki, err := pki.MarshalPKIXPublicKey(priv.Public().(*mayo.PublicKey))
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := pki.AlgorithmIdentifier(priv.Mayo())
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := marshalName(template.RawSubject, template.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributes []asn1.RawValue
+	if len(template.ExtraExtensions) > 0 {
+		extensions, err := asn1.Marshal(template.ExtraExtensions)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := asn1.Marshal(attribute{
+			Type:   oidExtensionRequest,
+			Values: []asn1.RawValue{{FullBytes: extensions}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, asn1.RawValue{FullBytes: encoded})
+	}
+
+	tbs, err := asn1.Marshal(certificationRequestInfo{
+		Subject:              asn1.RawValue{FullBytes: subject},
+		SubjectPublicKeyInfo: asn1.RawValue{FullBytes: spki},
+		Attributes:           attributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := priv.Sign(rand.Reader, tbs, crypto.Hash(0))
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificationRequest{
+		CertificationRequestInfo: asn1.RawValue{FullBytes: tbs},
+		SignatureAlgorithm:       algorithm,
+		SignatureValue:           asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)},
+	})
+}
+
+// ParseCertificateRequest parses a DER encoded certificate signing request, whose public key and signature are MAYO.
+// The signature is not checked, see CheckSignature.
+func ParseCertificateRequest(der []byte) (*CertificateRequest, error) {
+	var request certificationRequest
+	if rest, err := asn1.Unmarshal(der, &request); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("x509: trailing data after certificate request")
+	}
+
+	var info certificationRequestInfo
+	if rest, err := asn1.Unmarshal(request.CertificationRequestInfo.FullBytes, &info); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, errors.New("x509: trailing data after CertificationRequestInfo")
+	}
+
+	if info.Version != 0 {
+		return nil, errors.New("x509: only version 1 certificate requests are supported")
+	}
+	if request.SignatureValue.BitLength != 8*len(request.SignatureValue.Bytes) {
+		return nil, errors.New("x509: signature is not a whole number of bytes")
+	}
+
+	publicKey, err := pki.ParsePKIXPublicKey(info.SubjectPublicKeyInfo.FullBytes)
+	if err != nil {
+		return nil, err
+	}
+	signatureMayo, err := pki.ParseAlgorithmIdentifier(request.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	if signatureMayo.SecurityLevel() != publicKey.Mayo().SecurityLevel() {
+		return nil, errors.New("x509: signature algorithm does not match the public key")
+	}
+
+	r := &CertificateRequest{
+		Raw:                      der,
+		RawTBSCertificateRequest: info.Raw,
+		RawSubjectPublicKeyInfo:  info.SubjectPublicKeyInfo.FullBytes,
+		RawSubject:               info.Subject.FullBytes,
+		Version:                  info.Version,
+		PublicKey:                publicKey,
+		Signature:                request.SignatureValue.Bytes,
+	}
+
+	if err = parseName(r.RawSubject, &r.Subject); err != nil {
+		return nil, err
+	}
+	if r.Extensions, err = parseExtensionRequest(info.Attributes); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CheckSignature checks that the self-signature of r is valid
+func (r *CertificateRequest) CheckSignature() error {
+	if !mayo.Verify(r.PublicKey, r.RawTBSCertificateRequest, r.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// parseExtensionRequest returns the extensions of the extension request attribute, other attributes are ignored
+func parseExtensionRequest(attributes []asn1.RawValue) ([]pkix.Extension, error) {
+	var extensions []pkix.Extension
+	for _, raw := range attributes {
+		var a attribute
+		if rest, err := asn1.Unmarshal(raw.FullBytes, &a); err != nil {
+			return nil, err
+		} else if len(rest) > 0 {
+			return nil, errors.New("x509: trailing data after attribute")
+		}
+		if !a.Type.Equal(oidExtensionRequest) {
+			continue
+		}
+
+		for _, value := range a.Values {
+			var requested []pkix.Extension
+			if rest, err := asn1.Unmarshal(value.FullBytes, &requested); err != nil {
+				return nil, err
+			} else if len(rest) > 0 {
+				return nil, errors.New("x509: trailing data after extension request")
+			}
+			extensions = append(extensions, requested...)
+		}
+	}
+	return extensions, nil
+}
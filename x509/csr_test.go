@@ -0,0 +1,135 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"mayo-go/mayo"
+	"testing"
+	"time"
+)
+
+func TestCertificateRequestRoundTrip(t *testing.T) {
+	m, _ := mayo.InitMayo(2)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	extension := pkix.Extension{Id: asn1.ObjectIdentifier{1, 2, 3, 4}, Value: []byte{0x05, 0x00}}
+	der, err := CreateCertificateRequest(&CertificateRequest{
+		Subject:         pkix.Name{CommonName: "Leaf", Organization: []string{"mayo-go"}},
+		ExtraExtensions: []pkix.Extension{extension},
+	}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := DecodeCertificateRequestPEM(EncodeCertificateRequestPEM(der))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = request.CheckSignature(); err != nil {
+		t.Error(err)
+	}
+	if request.Subject.CommonName != "Leaf" {
+		t.Error("Subject not parsed correctly", request.Subject)
+	}
+	if !request.PublicKey.Equal(sk.Public()) {
+		t.Error("Public key not parsed correctly")
+	}
+	if len(request.Extensions) != 1 || !request.Extensions[0].Id.Equal(extension.Id) {
+		t.Error("Extensions not parsed correctly", request.Extensions)
+	}
+}
+
+func TestCertificateRequestWithoutExtensions(t *testing.T) {
+	m, _ := mayo.InitMayo(1)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := CreateCertificateRequest(&CertificateRequest{Subject: pkix.Name{CommonName: "Leaf"}}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = request.CheckSignature(); err != nil {
+		t.Error(err)
+	}
+	if len(request.Extensions) != 0 {
+		t.Error("Expected no extensions, got:", request.Extensions)
+	}
+}
+
+func TestTamperedCertificateRequestIsRejected(t *testing.T) {
+	m, _ := mayo.InitMayo(2)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := CreateCertificateRequest(&CertificateRequest{Subject: pkix.Name{CommonName: "Leaf"}}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Change the common name, which is part of the signed CertificationRequestInfo
+	tampered := bytes.Replace(bytes.Clone(der), []byte("Leaf"), []byte("Lead"), 1)
+	request, err := ParseCertificateRequest(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = request.CheckSignature(); !errors.Is(err, ErrInvalidSignature) {
+		t.Error("Expected ErrInvalidSignature, got:", err)
+	}
+}
+
+func TestIssueCertificateFromRequest(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+
+	m, _ := mayo.InitMayo(2)
+	_, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := CreateCertificateRequest(&CertificateRequest{Subject: pkix.Name{CommonName: "Leaf"}}, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = request.CheckSignature(); err != nil {
+		t.Fatal(err)
+	}
+
+	template := &Certificate{
+		SerialNumber: big.NewInt(2),
+		RawSubject:   request.RawSubject,
+		NotBefore:    now,
+		NotAfter:     now.Add(time.Hour),
+	}
+	der, err = CreateCertificate(template, root.certificate, request.PublicKey, root.privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = leaf.Verify(VerifyOptions{Roots: []*Certificate{root.certificate}, CurrentTime: now}); err != nil {
+		t.Error(err)
+	}
+	if leaf.Subject.CommonName != "Leaf" || !leaf.PublicKey.Equal(request.PublicKey) {
+		t.Error("Certificate does not match the request", leaf.Subject)
+	}
+}
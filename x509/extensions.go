@@ -0,0 +1,143 @@
+package x509
+
+import (
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"mayo-go/mayo"
+)
+
+// KeyUsage is the set of actions the key of a certificate is valid for, as in the key usage extension of RFC 5280
+type KeyUsage int
+
+const (
+	KeyUsageDigitalSignature KeyUsage = 1 << iota
+	KeyUsageContentCommitment
+	KeyUsageKeyEncipherment
+	KeyUsageDataEncipherment
+	KeyUsageKeyAgreement
+	KeyUsageCertSign
+	KeyUsageCRLSign
+)
+
+var (
+	oidExtensionSubjectKeyId     = asn1.ObjectIdentifier{2, 5, 29, 14}
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidExtensionAuthorityKeyId   = asn1.ObjectIdentifier{2, 5, 29, 35}
+)
+
+type basicConstraints struct {
+	IsCA bool `asn1:"optional"`
+}
+
+type authorityKeyId struct {
+	Id []byte `asn1:"optional,tag:0"`
+}
+
+// keyIdentifier computes the key identifier of pub, which is the leftmost 160 bits of the SHA-256 hash of the
+// subject public key, as in method 1 of RFC 7093
+func keyIdentifier(pub *mayo.PublicKey) []byte {
+	hash := sha256.Sum256(pub.Bytes())
+	return hash[:20]
+}
+
+// buildExtensions encodes the extensions of template, followed by its ExtraExtensions
+func buildExtensions(template *Certificate, subjectKeyId, authorityKeyIdentifier []byte) ([]pkix.Extension, error) {
+	var extensions []pkix.Extension
+
+	if template.BasicConstraintsValid {
+		value, err := asn1.Marshal(basicConstraints{IsCA: template.IsCA})
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionBasicConstraints, Critical: true, Value: value})
+	}
+
+	if template.KeyUsage != 0 {
+		value, err := marshalKeyUsage(template.KeyUsage)
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionKeyUsage, Critical: true, Value: value})
+	}
+
+	value, err := asn1.Marshal(subjectKeyId)
+	if err != nil {
+		return nil, err
+	}
+	extensions = append(extensions, pkix.Extension{Id: oidExtensionSubjectKeyId, Value: value})
+
+	if len(authorityKeyIdentifier) > 0 {
+		value, err = asn1.Marshal(authorityKeyId{Id: authorityKeyIdentifier})
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, pkix.Extension{Id: oidExtensionAuthorityKeyId, Value: value})
+	}
+
+	return append(extensions, template.ExtraExtensions...), nil
+}
+
+// parseExtensions sets the fields of c given by its extensions
+func parseExtensions(c *Certificate) error {
+	for _, extension := range c.Extensions {
+		var rest []byte
+		var err error
+
+		switch {
+		case extension.Id.Equal(oidExtensionBasicConstraints):
+			var constraints basicConstraints
+			rest, err = asn1.Unmarshal(extension.Value, &constraints)
+			c.BasicConstraintsValid = true
+			c.IsCA = constraints.IsCA
+		case extension.Id.Equal(oidExtensionKeyUsage):
+			var usage asn1.BitString
+			rest, err = asn1.Unmarshal(extension.Value, &usage)
+			c.KeyUsage = parseKeyUsage(usage)
+		case extension.Id.Equal(oidExtensionSubjectKeyId):
+			rest, err = asn1.Unmarshal(extension.Value, &c.SubjectKeyId)
+		case extension.Id.Equal(oidExtensionAuthorityKeyId):
+			var id authorityKeyId
+			rest, err = asn1.Unmarshal(extension.Value, &id)
+			c.AuthorityKeyId = id.Id
+		default:
+			if extension.Critical {
+				c.UnhandledCriticalExtensions = append(c.UnhandledCriticalExtensions, extension.Id)
+			}
+		}
+
+		if err != nil {
+			return err
+		} else if len(rest) > 0 {
+			return errors.New("x509: trailing data after extension " + extension.Id.String())
+		}
+	}
+
+	return nil
+}
+
+// marshalKeyUsage encodes the key usage as a BIT STRING, where bit i is the most significant bit first
+func marshalKeyUsage(usage KeyUsage) ([]byte, error) {
+	var bits [2]byte
+	bitLength := 0
+	for i := 0; i < 9; i++ {
+		if usage&(1<<i) != 0 {
+			bits[i/8] |= 0x80 >> (i % 8)
+			bitLength = i + 1
+		}
+	}
+	return asn1.Marshal(asn1.BitString{Bytes: bits[:(bitLength+7)/8], BitLength: bitLength})
+}
+
+// parseKeyUsage decodes the BIT STRING of the key usage extension
+func parseKeyUsage(bits asn1.BitString) KeyUsage {
+	var usage KeyUsage
+	for i := 0; i < 9; i++ {
+		if bits.At(i) != 0 {
+			usage |= 1 << i
+		}
+	}
+	return usage
+}
@@ -0,0 +1,41 @@
+package x509
+
+import (
+	"encoding/pem"
+	"errors"
+)
+
+// CertificatePEMType is the type of PEM blocks holding a certificate
+const CertificatePEMType = "CERTIFICATE"
+
+// EncodeCertificatePEM encodes a DER encoded certificate as a PEM block
+func EncodeCertificatePEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: CertificatePEMType, Bytes: der})
+}
+
+// DecodeCertificatePEM decodes and parses the first PEM block of data, which must hold a certificate
+func DecodeCertificatePEM(data []byte) (*Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != CertificatePEMType {
+		return nil, errors.New("x509: no certificate PEM block found")
+	}
+	return ParseCertificate(block.Bytes)
+}
+
+// CertificateRequestPEMType is the type of PEM blocks holding a certificate signing request
+const CertificateRequestPEMType = "CERTIFICATE REQUEST"
+
+// EncodeCertificateRequestPEM encodes a DER encoded certificate signing request as a PEM block
+func EncodeCertificateRequestPEM(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: CertificateRequestPEMType, Bytes: der})
+}
+
+// DecodeCertificateRequestPEM decodes and parses the first PEM block of data, which must hold a certificate signing
+// request
+func DecodeCertificateRequestPEM(data []byte) (*CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != CertificateRequestPEMType {
+		return nil, errors.New("x509: no certificate request PEM block found")
+	}
+	return ParseCertificateRequest(block.Bytes)
+}
@@ -0,0 +1,131 @@
+package x509
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mayo-go/mayo"
+	"mayo-go/pki"
+	"time"
+)
+
+// maxChainLength bounds the amount of certificates in a chain, including the leaf and the root
+const maxChainLength = 10
+
+var (
+	// ErrInvalidSignature is returned when the signature of a certificate is not valid
+	ErrInvalidSignature = errors.New("x509: invalid signature")
+
+	// ErrNoValidChain is returned when no chain from the certificate to one of the roots could be built
+	ErrNoValidChain = errors.New("x509: no valid chain to a root certificate")
+)
+
+// VerifyOptions are the options of Verify. If CurrentTime is zero, the current time is used
+type VerifyOptions struct {
+	Roots         []*Certificate
+	Intermediates []*Certificate
+	CurrentTime   time.Time
+}
+
+// CheckSignature checks that signature is a valid signature by the public key of c on signed
+func (c *Certificate) CheckSignature(signed, signature []byte) error {
+	if !mayo.Verify(c.PublicKey, signed, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// CheckSignatureFrom checks that the signature on c is a valid signature by parent, that its algorithm is the
+// parameter set of the public key of parent, and that parent is allowed to issue certificates
+func (c *Certificate) CheckSignatureFrom(parent *Certificate) error {
+	if !parent.BasicConstraintsValid || !parent.IsCA {
+		return errors.New("x509: parent certificate is not a CA")
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&KeyUsageCertSign == 0 {
+		return errors.New("x509: parent certificate is not allowed to sign certificates")
+	}
+	if !bytes.Equal(c.RawIssuer, parent.RawSubject) {
+		return errors.New("x509: issuer does not match the subject of the parent certificate")
+	}
+
+	signatureMayo, err := pki.ParseAlgorithmIdentifier(c.SignatureAlgorithm)
+	if err != nil {
+		return err
+	}
+	if signatureMayo.SecurityLevel() != parent.PublicKey.Mayo().SecurityLevel() {
+		return errors.New("x509: signature algorithm does not match the public key of the parent certificate")
+	}
+	return parent.CheckSignature(c.RawTBSCertificate, c.Signature)
+}
+
+// Verify builds a chain from c to one of opts.Roots through opts.Intermediates, checking the signatures, validity
+// periods and CA constraints along the way. The chain starts with c and ends with the root.
+func (c *Certificate) Verify(opts VerifyOptions) ([]*Certificate, error) {
+	currentTime := opts.CurrentTime
+	if currentTime.IsZero() {
+		currentTime = time.Now()
+	}
+
+	if err := c.isValid(currentTime); err != nil {
+		return nil, err
+	}
+	for _, root := range opts.Roots {
+		if c.Equal(root) {
+			return []*Certificate{c}, nil
+		}
+	}
+
+	return buildChain([]*Certificate{c}, opts, currentTime)
+}
+
+// buildChain extends chain with either a root or an intermediate, which issued the last certificate of the chain
+func buildChain(chain []*Certificate, opts VerifyOptions, currentTime time.Time) ([]*Certificate, error) {
+	if len(chain) >= maxChainLength {
+		return nil, ErrNoValidChain
+	}
+	last := chain[len(chain)-1]
+
+	for _, root := range opts.Roots {
+		if root.isValid(currentTime) == nil && last.CheckSignatureFrom(root) == nil {
+			return append(chain, root), nil
+		}
+	}
+
+	for _, intermediate := range opts.Intermediates {
+		if contains(chain, intermediate) || intermediate.isValid(currentTime) != nil {
+			continue
+		}
+		if last.CheckSignatureFrom(intermediate) != nil {
+			continue
+		}
+
+		extendedChain := append(append([]*Certificate(nil), chain...), intermediate)
+		if result, err := buildChain(extendedChain, opts, currentTime); err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, ErrNoValidChain
+}
+
+// isValid checks the validity period and critical extensions of c
+func (c *Certificate) isValid(currentTime time.Time) error {
+	if currentTime.Before(c.NotBefore) || currentTime.After(c.NotAfter) {
+		return fmt.Errorf("x509: certificate with serial number %s is not valid at %s", c.SerialNumber,
+			currentTime.Format(time.RFC3339))
+	}
+	if len(c.UnhandledCriticalExtensions) > 0 {
+		return fmt.Errorf("x509: unhandled critical extension %s", c.UnhandledCriticalExtensions[0])
+	}
+	return nil
+}
+
+// contains checks if the certificate is in the chain
+func contains(chain []*Certificate, c *Certificate) bool {
+	for _, existing := range chain {
+		if existing.Equal(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"mayo-go/mayo"
+	"mayo-go/pki"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type issuedCertificate struct {
+	certificate *Certificate
+	privateKey  *mayo.PrivateKey
+}
+
+// issue creates a certificate for a new key of the given security level, which is signed by parent or self-signed
+func issue(t *testing.T, securityLevel int, commonName string, isCA bool, parent *issuedCertificate) *issuedCertificate {
+	t.Helper()
+
+	m, err := mayo.InitMayo(securityLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"mayo-go"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              KeyUsageDigitalSignature,
+	}
+	if isCA {
+		template.KeyUsage |= KeyUsageCertSign
+	}
+
+	issuerCertificate, issuerKey := template, sk
+	if parent != nil {
+		issuerCertificate, issuerKey = parent.certificate, parent.privateKey
+	}
+
+	der, err := CreateCertificate(template, issuerCertificate, pk, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &issuedCertificate{certificate: certificate, privateKey: sk}
+}
+
+func TestSelfSignedCertificateRoundTrip(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+	c := root.certificate
+
+	if c.Subject.CommonName != "Root CA" || c.Issuer.CommonName != "Root CA" {
+		t.Error("Subject or issuer not parsed correctly", c.Subject, c.Issuer)
+	}
+	if !c.BasicConstraintsValid || !c.IsCA {
+		t.Error("Basic constraints not parsed correctly")
+	}
+	if c.KeyUsage != KeyUsageDigitalSignature|KeyUsageCertSign {
+		t.Error("Key usage not parsed correctly", c.KeyUsage)
+	}
+	if !c.NotBefore.Equal(now.Add(-time.Hour)) || !c.NotAfter.Equal(now.Add(24*time.Hour)) {
+		t.Error("Validity not parsed correctly", c.NotBefore, c.NotAfter)
+	}
+	if !c.PublicKey.Equal(root.privateKey.Public()) {
+		t.Error("Public key not parsed correctly")
+	}
+	if len(c.SubjectKeyId) != 20 || string(c.SubjectKeyId) != string(c.AuthorityKeyId) {
+		t.Error("Key identifiers not set correctly", c.SubjectKeyId, c.AuthorityKeyId)
+	}
+	if err := c.CheckSignatureFrom(c); err != nil {
+		t.Error(err)
+	}
+
+	decoded, err := DecodeCertificatePEM(EncodeCertificatePEM(c.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(c) {
+		t.Error("Certificate did not survive PEM round trip")
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+	intermediate := issue(t, 1, "Intermediate CA", true, root)
+	leaf := issue(t, 2, "Leaf", false, intermediate)
+
+	chain, err := leaf.certificate.Verify(VerifyOptions{
+		Roots:         []*Certificate{root.certificate},
+		Intermediates: []*Certificate{intermediate.certificate},
+		CurrentTime:   now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*Certificate{leaf.certificate, intermediate.certificate, root.certificate}
+	if len(chain) != len(expected) {
+		t.Fatal("Chain has wrong length", len(chain))
+	}
+	for i := range chain {
+		if !chain[i].Equal(expected[i]) {
+			t.Error("Chain has wrong certificate at index", i, chain[i].Subject)
+		}
+	}
+	if leaf.certificate.Issuer.CommonName != "Intermediate CA" {
+		t.Error("Issuer of the leaf should be the intermediate", leaf.certificate.Issuer)
+	}
+	if string(leaf.certificate.AuthorityKeyId) != string(intermediate.certificate.SubjectKeyId) {
+		t.Error("Authority key identifier of the leaf should be the subject key identifier of the intermediate")
+	}
+}
+
+func TestVerifyRejectsInvalidChains(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+	otherRoot := issue(t, 2, "Other Root CA", true, nil)
+	intermediate := issue(t, 2, "Intermediate CA", true, root)
+	leaf := issue(t, 2, "Leaf", false, intermediate)
+	leafOfLeaf := issue(t, 2, "Leaf of leaf", false, leaf)
+
+	// Missing intermediate
+	if _, err := leaf.certificate.Verify(VerifyOptions{
+		Roots:       []*Certificate{root.certificate},
+		CurrentTime: now,
+	}); !errors.Is(err, ErrNoValidChain) {
+		t.Error("Expected ErrNoValidChain for missing intermediate, got:", err)
+	}
+
+	// Wrong root
+	if _, err := leaf.certificate.Verify(VerifyOptions{
+		Roots:         []*Certificate{otherRoot.certificate},
+		Intermediates: []*Certificate{intermediate.certificate},
+		CurrentTime:   now,
+	}); !errors.Is(err, ErrNoValidChain) {
+		t.Error("Expected ErrNoValidChain for wrong root, got:", err)
+	}
+
+	// Issued by a certificate which is not a CA
+	if _, err := leafOfLeaf.certificate.Verify(VerifyOptions{
+		Roots:         []*Certificate{root.certificate},
+		Intermediates: []*Certificate{intermediate.certificate, leaf.certificate},
+		CurrentTime:   now,
+	}); !errors.Is(err, ErrNoValidChain) {
+		t.Error("Expected ErrNoValidChain for non-CA issuer, got:", err)
+	}
+
+	// Expired
+	if _, err := leaf.certificate.Verify(VerifyOptions{
+		Roots:         []*Certificate{root.certificate},
+		Intermediates: []*Certificate{intermediate.certificate},
+		CurrentTime:   now.Add(48 * time.Hour),
+	}); err == nil {
+		t.Error("Expected error for expired certificate")
+	}
+}
+
+func TestTamperedCertificateIsRejected(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+	leaf := issue(t, 2, "Leaf", false, root)
+
+	tampered, err := ParseCertificate(bytes.Clone(leaf.certificate.Raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered.Signature[0] ^= 1
+
+	if err = tampered.CheckSignatureFrom(root.certificate); !errors.Is(err, ErrInvalidSignature) {
+		t.Error("Expected ErrInvalidSignature, got:", err)
+	}
+}
+
+func TestCheckSignatureFromRejectsAlgorithmMismatch(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+	leaf := issue(t, 2, "Leaf", false, root)
+
+	// The signature itself remains valid, only the algorithm claims another parameter set
+	level1, err := mayo.InitMayo(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf.certificate.SignatureAlgorithm, err = pki.AlgorithmIdentifier(level1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = leaf.certificate.CheckSignatureFrom(root.certificate); err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Error("Expected an algorithm mismatch error, got:", err)
+	}
+}
+
+func TestCreateCertificateRejectsWrongIssuerKey(t *testing.T) {
+	root := issue(t, 2, "Root CA", true, nil)
+
+	m, _ := mayo.InitMayo(2)
+	pk, sk, err := m.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Leaf"},
+		NotBefore:    now,
+		NotAfter:     now.Add(time.Hour),
+	}
+	if _, err = CreateCertificate(template, root.certificate, pk, sk); err == nil {
+		t.Error("Expected error when signing with a key that is not the key of the issuer")
+	}
+}